@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"contribution-painter/internal/pkg/plan"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyPlan  string
+	applyForce bool
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Execute a plan written by the plan command",
+	Long: `Execute exactly the commits of a plan written by the plan command and push them to the repo
+of the config, unless dry_run is set. It refuses to apply if your contribution calendar has
+changed since the plan was made, make a new plan in that case. It also refuses a plan made
+from another config, unless --force is set.`,
+	Run: applyFunc,
+}
+
+var applyFunc = func(cmd *cobra.Command, args []string) {
+	p, err := plan.Load(applyPlan)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "load plan failed:", err)
+		os.Exit(1)
+	}
+
+	re := rewriter.NewRewriter(config)
+	if err = re.Apply(p, applyForce); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "apply plan failed:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringVar(&applyPlan, "plan", "", "path of the plan file")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "apply the plan made from another config")
+	_ = applyCmd.MarkFlagRequired("plan")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// eraseCmd represents the erase command
+var eraseCmd = &cobra.Command{
+	Use:   "erase",
+	Short: "Remove a previous painting from the repo",
+	Long: `Remove a previous painting by rewriting the branch without the commits created by the painter,
+the other commits are kept. The commits and days to remove are reported before force pushing,
+nothing is pushed if dry_run is set. The branch is backed up like painting.`,
+	Run: eraseFunc,
+}
+
+var eraseFunc = func(cmd *cobra.Command, args []string) {
+	re := rewriter.NewRewriter(config)
+	report, err := re.Erase()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "erase failed:", err)
+		os.Exit(1)
+	}
+
+	verb := "erased"
+	if config.Rewriter.DryRun {
+		verb = "would erase"
+	}
+	fmt.Printf("%s %d commits on %d days\n", verb, report.Commits, len(report.Days))
+}
+
+func init() {
+	rootCmd.AddCommand(eraseCmd)
+}
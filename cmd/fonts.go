@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"contribution-painter/internal/pkg/simulate"
+	"fmt"
+	"os"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+var sampleText string
+
+// fontsCmd represents the fonts command
+var fontsCmd = &cobra.Command{
+	Use:   "fonts",
+	Short: "Manage the fonts to paint with",
+}
+
+// fontsListCmd represents the fonts list command
+var fontsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered fonts with a sample render",
+	Long: `List the registered fonts with a sample render, including the built-in fonts
+and the font loaded from font_file of the config.`,
+	Run: fontsListFunc,
+}
+
+var fontsListFunc = func(cmd *cobra.Command, args []string) {
+	if config.Rewriter.FontFile != "" {
+		if _, err := dict.NewDictionaryFromFile(config.Rewriter.FontFile); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "load font file failed:", err)
+			os.Exit(1)
+		}
+	}
+
+	for _, face := range dict.Fonts() {
+		fmt.Printf("%s  height: %d, width: %d, glyphs: %d\n", face.Name, face.Height, face.Width(), len(face.Glyphs))
+
+		letters, err := dict.NewDictionary(face.Name).GetLetters(sampleOf(face, sampleText), 1, 0, 0)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "get letters failed:", err)
+			os.Exit(1)
+		}
+		if err = simulate.RenderLetters(os.Stdout, letters); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "render letters failed:", err)
+			os.Exit(1)
+		}
+		fmt.Println()
+	}
+}
+
+// sampleOf returns the runes of text the font has glyphs for, falling back to uppercase
+func sampleOf(face *domain.FontFace, text string) string {
+	var sample []rune
+	for _, r := range text {
+		if _, ok := face.Glyphs[r]; ok {
+			sample = append(sample, r)
+		} else if _, ok = face.Glyphs[unicode.ToUpper(r)]; ok {
+			sample = append(sample, unicode.ToUpper(r))
+		}
+	}
+	return string(sample)
+}
+
+func init() {
+	rootCmd.AddCommand(fontsCmd)
+	fontsCmd.AddCommand(fontsListCmd)
+
+	fontsListCmd.Flags().StringVar(&sampleText, "text", "Hello 2024!", "sample text to render")
+}
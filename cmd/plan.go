@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var planOutput string
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Write the plan of the painting to a file",
+	Long: `Write the plan of the painting to a file without touching the repo, including the commits
+to create by day, the hash of the config, the snapshot of your contribution calendar and the author.
+The plan is written in YAML if the file ends with .yaml or .yml, otherwise in JSON, and can be
+executed later with apply --plan.`,
+	Run: planFunc,
+}
+
+var planFunc = func(cmd *cobra.Command, args []string) {
+	re := rewriter.NewRewriter(config)
+	p, err := re.Plan()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "plan failed:", err)
+		os.Exit(1)
+	}
+
+	if err = p.Save(planOutput); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "save plan failed:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("plan %s of %d commits on %d days written to %s\n", p.ID, p.Total(), len(p.Commits), planOutput)
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().StringVarP(&planOutput, "output", "o", "plan.json", "path of the plan file")
+}
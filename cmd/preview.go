@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/render"
+	"contribution-painter/internal/pkg/stat"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	previewOutput   string
+	previewTheme    string
+	previewTerminal bool
+	previewCompare  bool
+	previewColors   string
+)
+
+// previewCmd represents the preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Render the predicted contribution graph as SVG and PNG",
+	Long: `Render the predicted contribution graph as SVG and PNG, which combines your current
+contribution calendar with the commits planned by the config, coloured like GitHub.
+Files are written to <output>-<theme>.svg and <output>-<theme>.png.
+
+With --terminal, the graph is printed to the terminal in the dark theme unless --theme is set, with
+--compare, the current graph is printed beside it.`,
+	Run: previewFunc,
+}
+
+var previewFunc = func(cmd *cobra.Command, args []string) {
+	themes := render.Themes()
+	if previewTheme != "" {
+		theme, err := render.ThemeByName(previewTheme)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		themes = []render.Theme{theme}
+	}
+
+	re := rewriter.NewRewriter(config)
+	predicted, err := re.PredictCalendar()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "predict calendar failed:", err)
+		os.Exit(1)
+	}
+	days := toRenderDays(predicted)
+
+	if previewTerminal {
+		theme := render.ThemeDark
+		if previewTheme != "" {
+			theme = themes[0]
+		}
+		if err = previewInTerminal(days, theme, re.CalendarRange()); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "render preview failed:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, theme := range themes {
+		for ext, renderFunc := range map[string]func(io.Writer, []render.Day, render.Theme) error{
+			"svg": render.SVG,
+			"png": render.PNG,
+		} {
+			path := fmt.Sprintf("%s-%s.%s", previewOutput, theme.Name, ext)
+			if err = writeFile(path, func(w io.Writer) error { return renderFunc(w, days, theme) }); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, "render preview failed:", err)
+				os.Exit(1)
+			}
+			fmt.Println("preview written to", path)
+		}
+	}
+}
+
+func previewInTerminal(days []render.Day, theme render.Theme, calendarRange graphql.DateRange) error {
+	mode := render.DetectColorMode()
+	switch previewColors {
+	case "":
+	case "truecolor":
+		mode = render.TrueColor
+	case "256":
+		mode = render.Color256
+	default:
+		return fmt.Errorf("unknown colors: %s", previewColors)
+	}
+
+	if !previewCompare {
+		return render.ANSI(os.Stdout, days, theme, mode)
+	}
+
+	current, err := stat.NewContributionStats(graphql.NewGhGraphql(config.GitInfo)).CommitsByDay(calendarRange)
+	if err != nil {
+		return fmt.Errorf("get commits by day failed: %w", err)
+	}
+	return render.ANSICompare(os.Stdout, toRenderDays(current), days, theme, mode)
+}
+
+// toRenderDays colours the commits by day with the levels predicted from the whole calendar
+func toRenderDays(commitStats []stat.CommitStat) []render.Day {
+	counts := make([]int, len(commitStats))
+	for i, cs := range commitStats {
+		counts[i] = cs.Commits
+	}
+	thresholds := stat.PredictThresholds(counts)
+
+	days := make([]render.Day, len(commitStats))
+	for i, cs := range commitStats {
+		days[i] = render.Day{Date: cs.Date, Count: cs.Commits, Level: thresholds.Level(cs.Commits)}
+	}
+	return days
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().StringVarP(&previewOutput, "output", "o", "preview", "path prefix of the preview files")
+	previewCmd.Flags().StringVar(&previewTheme, "theme", "", "theme of the preview, light or dark, both if not set")
+	previewCmd.Flags().BoolVar(&previewTerminal, "terminal", false, "print the preview to the terminal instead of files")
+	previewCmd.Flags().BoolVar(&previewCompare, "compare", false, "print the current graph beside the preview in the terminal")
+	previewCmd.Flags().StringVar(&previewColors, "colors", "", "colours of the terminal, truecolor or 256, detected from COLORTERM if not set")
+}
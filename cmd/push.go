@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// pushCmd represents the push command
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Push the painted repo of local_path",
+	Long: `Push the painted repo on disk of git_info.local_path, e.g. after painting with dry_run
+and inspecting the commits with git. The branch is force pushed after backing up the remote
+branch, or pushed fast-forward on top of the remote branch if git_info.append_only is set.`,
+	Run: pushFunc,
+}
+
+var pushFunc = func(cmd *cobra.Command, args []string) {
+	re := rewriter.NewRewriter(config)
+	if err := re.Push(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "push failed:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+}
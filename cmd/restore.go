@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreBundle string
+	restoreRef    string
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Put the branch on the remote back to a backup",
+	Long: `Put the branch on the remote back exactly as it was before painting, from a bundle file
+written before force pushing, or from a backup ref refs/painter-backup/<timestamp> on the remote
+to git_info.branch (the default branch if not set). A branch that didn't exist on the remote before
+painting is deleted.`,
+	Run: restoreFunc,
+}
+
+var restoreFunc = func(cmd *cobra.Command, args []string) {
+	if (restoreBundle == "") == (restoreRef == "") {
+		_, _ = fmt.Fprintln(os.Stderr, "either --bundle or --ref should be set")
+		os.Exit(1)
+	}
+
+	re := rewriter.NewRewriter(config)
+	if err := re.Restore(restoreBundle, restoreRef); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "restore failed:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringVar(&restoreBundle, "bundle", "", "path of the backup bundle file")
+	restoreCmd.Flags().StringVar(&restoreRef, "ref", "", "backup ref on the remote, e.g. refs/painter-backup/20240101T000000Z")
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"contribution-painter/internal/app/rewriter"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Print the painting of the config on the contribution calendar",
+	Long: `Print the painting of the config on the contribution calendar of the trailing year,
+laid out from the same start date and with the same letters, font and columns as the painting.
+Exits with a non-zero code if the painting doesn't fit before the current week.`,
+	Run: simulateFunc,
+}
+
+var simulateFunc = func(cmd *cobra.Command, args []string) {
+	re := rewriter.NewRewriter(config)
+	if err := re.Simulate(os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "painting doesn't fit:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+}
@@ -5,10 +5,12 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"contribution-painter/internal/app/rewriter"
 	"contribution-painter/internal/pkg/graphql"
 	"contribution-painter/internal/pkg/stat"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -20,11 +22,23 @@ var suggestCmd = &cobra.Command{
 	Long: `Give suggested config values for the following based on your existing commit history:
 background_commits_per_day
 foreground_commits_per_day
+
+With --predict, the colours GitHub assigns by the quartiles of the daily counts are predicted for the
+painting of the config, and the minimal values are given for the following instead:
+background_commits_per_day
+commits_per_level
 `,
 	Run: suggestFunc,
 }
 
+var predict bool
+
 var suggestFunc = func(cmd *cobra.Command, args []string) {
+	if predict {
+		predictFunc()
+		return
+	}
+
 	ghGraphql := graphql.NewGhGraphql(config.GitInfo)
 	stats := stat.NewContributionStats(ghGraphql)
 
@@ -50,9 +64,28 @@ var suggestFunc = func(cmd *cobra.Command, args []string) {
 	fmt.Printf("foreground_commits_per_day: %d\n", cfg.ForegroundCommitsPerDay)
 }
 
+func predictFunc() {
+	re := rewriter.NewRewriter(config)
+	background, perLevel, err := re.SuggestCommitsPerLevel()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "predict commits per level failed:", err)
+		os.Exit(1)
+	}
+
+	levels := make([]string, len(perLevel))
+	for i, commits := range perLevel {
+		levels[i] = fmt.Sprint(commits)
+	}
+	fmt.Println("suggested config:")
+	fmt.Printf("background_commits_per_day: %d\n", background)
+	fmt.Printf("commits_per_level: [%s]\n", strings.Join(levels, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(suggestCmd)
 
+	suggestCmd.Flags().BoolVar(&predict, "predict", false, "predict the colours of the painting and suggest the commits per level")
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
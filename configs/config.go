@@ -1,21 +1,56 @@
 package configs
 
 type GitInfo struct {
-	RepoUrl string `mapstructure:"repo_url"`
-	GhToken string `mapstructure:"gh_token"`
-	Author  string `mapstructure:"author"`
-	Email   string `mapstructure:"email"`
+	RepoUrl          string `mapstructure:"repo_url"`
+	GhToken          string `mapstructure:"gh_token"`
+	SSHKeyFile       string `mapstructure:"ssh_key_file"`
+	SSHKeyPassphrase string `mapstructure:"ssh_key_passphrase"`
+	Author           string `mapstructure:"author"`
+	Email            string `mapstructure:"email"`
+	LocalPath        string `mapstructure:"local_path"`
+	Branch           string `mapstructure:"branch"`
+	Orphan           bool   `mapstructure:"orphan"`
+	AppendOnly       bool   `mapstructure:"append_only"`
+	Backup           string `mapstructure:"backup"`
+	BackupDir        string `mapstructure:"backup_dir"`
 }
 
 type Rewriter struct {
-	DryRun                  bool   `mapstructure:"dry_run"`
-	BackgroundCommitsPerDay int    `mapstructure:"background_commits_per_day"`
-	ForegroundCommitsPerDay int    `mapstructure:"foreground_commits_per_day"`
-	TargetLetters           string `mapstructure:"target_letters"`
-	LeadingColumns          int    `mapstructure:"leading_columns"`
-	TrailingColumns         int    `mapstructure:"trailing_columns"`
-	LetterSpacing           int    `mapstructure:"letter_spacing"`
-	Font                    string `mapstructure:"font"`
+	DryRun                  bool      `mapstructure:"dry_run"`
+	Year                    int       `mapstructure:"year"`
+	StartDate               string    `mapstructure:"start_date"`
+	Timezone                string    `mapstructure:"timezone"`
+	TimeDistribution        string    `mapstructure:"time_distribution"`
+	WorkingHours            []int     `mapstructure:"working_hours"`
+	TimeSeed                int64     `mapstructure:"time_seed"`
+	TimeHistogram           []int     `mapstructure:"time_histogram"`
+	Content                 string    `mapstructure:"content"`
+	ContentPath             string    `mapstructure:"content_path"`
+	ContentTemplate         string    `mapstructure:"content_template"`
+	SnippetsFile            string    `mapstructure:"snippets_file"`
+	Source                  string    `mapstructure:"source"`
+	ImageFile               string    `mapstructure:"image_file"`
+	CanvasFile              string    `mapstructure:"canvas_file"`
+	BackgroundCommitsPerDay int       `mapstructure:"background_commits_per_day"`
+	ForegroundCommitsPerDay int       `mapstructure:"foreground_commits_per_day"`
+	CommitsPerLevel         []int     `mapstructure:"commits_per_level"`
+	ForegroundLevel         int       `mapstructure:"foreground_level"`
+	ShadowLevel             int       `mapstructure:"shadow_level"`
+	TargetLetters           string    `mapstructure:"target_letters"`
+	LeadingColumns          int       `mapstructure:"leading_columns"`
+	TrailingColumns         int       `mapstructure:"trailing_columns"`
+	LetterSpacing           int       `mapstructure:"letter_spacing"`
+	Font                    string    `mapstructure:"font"`
+	FontFile                string    `mapstructure:"font_file"`
+	Case                    string    `mapstructure:"case"`
+	Proportional            bool      `mapstructure:"proportional"`
+	Kerning                 []Kerning `mapstructure:"kerning"`
+}
+
+// Kerning adjusts the letter spacing between a pair of letters, e.g. {pair: "LT", adjust: -1}
+type Kerning struct {
+	Pair   string `mapstructure:"pair"`
+	Adjust int    `mapstructure:"adjust"`
 }
 
 type Configuration struct {
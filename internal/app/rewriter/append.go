@@ -0,0 +1,62 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/repo"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+// push pushes the painting on top of the remote branch without rewriting it, it fails if the remote branch
+// has moved since preparing, as the painting is not on top of it anymore
+func (r *Rewriter) push() error {
+	if err := r.checkRemoteTip(); err != nil {
+		return err
+	}
+
+	err := repo.Push(r.repo, r.auth, r.gitCfg.Branch)
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logrus.Info("remote is already up-to-date")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+	logrus.Info("push success")
+	return nil
+}
+
+// fastForward moves the local branch to the tip of the remote branch recorded in preparing, so the painting
+// goes on top of it, it fails if the local branch has diverged from it, e.g. the repo on disk was erased in a dry run
+func (r *Rewriter) fastForward() error {
+	if r.remoteTip == nil {
+		return nil
+	}
+	if err := repo.FastForward(r.repo, r.gitCfg.Branch, r.remoteTip.Hash()); err != nil {
+		return fmt.Errorf("local branch is not on top of the remote branch: %w", err)
+	}
+	return nil
+}
+
+// checkRemoteTip fails if the tip of the remote branch is not the one recorded in preparing
+func (r *Rewriter) checkRemoteTip() error {
+	tip, err := repo.RemoteBranch(r.repo, r.auth, r.gitCfg.Branch)
+	if err != nil {
+		return fmt.Errorf("get remote branch failed: %w", err)
+	}
+
+	before, after := plumbing.ZeroHash, plumbing.ZeroHash
+	if r.remoteTip != nil {
+		before = r.remoteTip.Hash()
+	}
+	if tip != nil {
+		after = tip.Hash()
+	}
+	if before != after {
+		return fmt.Errorf("remote branch has moved during painting: %s -> %s", before, after)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/repo"
+	"contribution-painter/internal/pkg/stat"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_Apply_AppendOnly(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := []int{0, 1, 0}
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	var calendar []stat.CommitStat
+	for i, count := range counts {
+		calendar = append(calendar, stat.CommitStat{Date: start.AddDate(0, 0, i), Commits: count})
+	}
+	gitCfg := configs.GitInfo{Author: "painter", Email: "painter@example.com", AppendOnly: true}
+	commits := map[plan.Layer][]stat.CommitStat{plan.LayerBackground: {{Date: start, Commits: 2}}}
+
+	t.Run("painting is pushed on top of the remote branch", func(t *testing.T) {
+		remotePath, remote := newRemoteRepo(t)
+		original, err := remote.Head()
+		assert.NoError(t, err)
+
+		gitCfg.RepoUrl = remotePath
+		r := &Rewriter{gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+		assert.NoError(t, r.Apply(plan.New(r.config(), calendar, commits), false))
+
+		head, err := remote.Head()
+		assert.NoError(t, err)
+		commits, err := remote.Log(&git.LogOptions{From: head.Hash()})
+		assert.NoError(t, err)
+		var messages []string
+		_ = commits.ForEach(func(c *object.Commit) error {
+			messages = append(messages, strings.SplitN(c.Message, "\n", 2)[0])
+			return nil
+		})
+		assert.Equal(t, []string{"Arbitrary commit #2", "Arbitrary commit #1", "initial commit"}, messages)
+		assert.NotEqual(t, original.Hash(), head.Hash())
+		// nothing is backed up as nothing is rewritten
+		bundles, err := filepath.Glob("painter-backup-*.bundle")
+		assert.NoError(t, err)
+		assert.Empty(t, bundles)
+	})
+
+	t.Run("repo on disk behind the remote branch is fast-forwarded before painting", func(t *testing.T) {
+		remotePath, remote := newRemoteRepo(t)
+		local := filepath.Join(t.TempDir(), "local")
+		_, err := repo.OpenOrCloneRepo(local, remotePath, nil)
+		assert.NoError(t, err)
+
+		other := &Rewriter{gitCfg: configs.GitInfo{RepoUrl: remotePath, Author: "someone", Email: "someone@example.com"}}
+		assert.NoError(t, other.openRepo())
+		pushed := other.createCommit(start, "someone else's commit")
+		assert.NoError(t, other.commitToWorkTree([]dailyCommit{pushed}))
+		assert.NoError(t, repo.Push(other.repo, other.auth, ""))
+
+		cfg := gitCfg
+		cfg.RepoUrl, cfg.LocalPath = remotePath, local
+		r := &Rewriter{gitCfg: cfg, stats: newMockStats(mockServer.URL)}
+		assert.NoError(t, r.Apply(plan.New(r.config(), calendar, commits), false))
+
+		head, err := remote.Head()
+		assert.NoError(t, err)
+		commits, err := remote.Log(&git.LogOptions{From: head.Hash()})
+		assert.NoError(t, err)
+		var messages []string
+		_ = commits.ForEach(func(c *object.Commit) error {
+			messages = append(messages, strings.SplitN(c.Message, "\n", 2)[0])
+			return nil
+		})
+		assert.Equal(t, []string{"Arbitrary commit #2", "Arbitrary commit #1", "someone else's commit", "initial commit"},
+			messages)
+	})
+
+	t.Run("repo on disk diverged from the remote branch should return error", func(t *testing.T) {
+		remotePath, _ := newRemoteRepo(t)
+		local := filepath.Join(t.TempDir(), "local")
+		onDisk, err := repo.OpenOrCloneRepo(local, remotePath, nil)
+		assert.NoError(t, err)
+
+		other := &Rewriter{gitCfg: configs.GitInfo{RepoUrl: remotePath, Author: "someone", Email: "someone@example.com"}}
+		assert.NoError(t, other.openRepo())
+		assert.NoError(t, other.commitToWorkTree([]dailyCommit{other.createCommit(start, "someone else's commit")}))
+		assert.NoError(t, repo.Push(other.repo, other.auth, ""))
+
+		// fetched, then committed on disk without pulling
+		_, err = repo.RemoteBranch(onDisk, nil, "")
+		assert.NoError(t, err)
+		cfg := gitCfg
+		cfg.RepoUrl, cfg.LocalPath = remotePath, local
+		diverged := &Rewriter{gitCfg: cfg, repo: onDisk}
+		assert.NoError(t, diverged.commitToWorkTree([]dailyCommit{diverged.createCommit(start, "local commit")}))
+
+		r := &Rewriter{gitCfg: cfg, stats: newMockStats(mockServer.URL)}
+		assert.ErrorIs(t, r.Apply(plan.New(r.config(), calendar, commits), false), repo.ErrDiverged)
+	})
+
+	t.Run("remote branch moved during painting should return error", func(t *testing.T) {
+		remotePath, _ := newRemoteRepo(t)
+		gitCfg.RepoUrl = remotePath
+		r := &Rewriter{gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+		assert.NoError(t, r.prepare(plan.New(r.config(), calendar, commits)))
+
+		other := &Rewriter{gitCfg: gitCfg}
+		assert.NoError(t, other.openRepo())
+		assert.NoError(t, other.commitToWorkTree([]dailyCommit{other.createCommit(start, "someone else's commit")}))
+		assert.NoError(t, repo.Push(other.repo, other.auth, ""))
+
+		assert.NoError(t, r.commitToWorkTree([]dailyCommit{r.createCommit(start, "Arbitrary commit #1")}))
+		assert.ErrorContains(t, r.push(), "remote branch has moved during painting")
+	})
+}
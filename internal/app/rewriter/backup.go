@@ -0,0 +1,131 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/repo"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	backupBundle = "bundle"
+	backupRef    = "ref"
+	backupBoth   = "both"
+	backupNone   = "none"
+
+	backupTimeFormat = "20060102T150405Z"
+)
+
+// forcePush backs up the branch on the remote before force pushing the painting
+func (r *Rewriter) forcePush() error {
+	if err := r.backup(time.Now()); err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	err := repo.ForcePush(r.repo, r.auth, r.gitCfg.Branch)
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logrus.Info("remote is already up-to-date")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("force push failed: %w", err)
+	}
+	logrus.Info("force push success")
+	return nil
+}
+
+// backup saves the branch on the remote as it is to a bundle file in the backup dir, to a backup ref
+// on the remote, or both, named after the time
+func (r *Rewriter) backup(now time.Time) error {
+	mode := r.gitCfg.Backup
+	if mode == "" {
+		mode = backupBundle
+	}
+	switch mode {
+	case backupBundle, backupRef, backupBoth:
+	case backupNone:
+		logrus.Warn("backup is disabled, the branch on the remote can't be restored")
+		return nil
+	default:
+		return fmt.Errorf("unknown backup: %s", mode)
+	}
+
+	branch, err := repo.RemoteBranch(r.repo, r.auth, r.gitCfg.Branch)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		// a marker in place of the branch, so restoring deletes the painted branch
+		logrus.Info("the branch doesn't exist on the remote, backing up a marker to delete it on restore")
+		if branch, err = repo.AbsentBranch(r.repo, r.gitCfg.Branch); err != nil {
+			return err
+		}
+	}
+
+	name := now.UTC().Format(backupTimeFormat)
+	if mode == backupBundle || mode == backupBoth {
+		dir := r.gitCfg.BackupDir
+		if dir == "" {
+			dir = "."
+		}
+		if err = os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, fmt.Sprintf("painter-backup-%s.bundle", name))
+		if err = repo.WriteBundle(r.repo, branch, path); err != nil {
+			return err
+		}
+		logrus.Infof("restore with: restore --bundle %s", path)
+	}
+	if mode == backupRef || mode == backupBoth {
+		ref, err := repo.BackupToRef(r.repo, r.auth, branch, name)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("restore with: restore --ref %s", ref)
+	}
+	return nil
+}
+
+// Restore force pushes the branch on the remote back to a backup, from the bundle file if it is set,
+// otherwise from the backup ref on the remote to the configured branch, the branch is deleted if it didn't
+// exist on the remote at the backup
+func (r *Rewriter) Restore(bundle, ref string) (err error) {
+	if err = r.newAuth(); err != nil {
+		return err
+	}
+	// the repo on disk of the local path is left alone
+	r.repo, err = repo.CloneRepo(r.gitCfg.RepoUrl, r.auth)
+	if err != nil {
+		return fmt.Errorf("clone repo failed: %w", err)
+	}
+
+	branch, hash := r.gitCfg.Branch, plumbing.ZeroHash
+	if bundle != "" {
+		refs, err := repo.ReadBundle(r.repo, bundle)
+		if err != nil {
+			return fmt.Errorf("read bundle failed: %w", err)
+		}
+		if len(refs) != 1 || !refs[0].Name().IsBranch() {
+			return fmt.Errorf("bundle should have exactly one branch: %s", bundle)
+		}
+		branch, hash = refs[0].Name().Short(), refs[0].Hash()
+	} else {
+		hash, err = repo.FetchBackupRef(r.repo, r.auth, ref)
+		if err != nil {
+			return fmt.Errorf("fetch backup ref failed: %w", err)
+		}
+	}
+
+	if err = repo.RestoreBranch(r.repo, r.auth, branch, hash); err != nil {
+		return fmt.Errorf("restore branch failed: %w", err)
+	}
+	logrus.Info("restore success")
+	return nil
+}
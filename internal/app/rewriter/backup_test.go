@@ -0,0 +1,109 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/repo"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRemoteRepo creates a bare repo on disk with an empty commit to stand in for the remote
+func newRemoteRepo(t *testing.T) (string, *git.Repository) {
+	source := filepath.Join(t.TempDir(), "source")
+	r, err := git.PlainInit(source, false)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	_, err = w.Commit("initial commit", &git.CommitOptions{
+		Author:            &object.Signature{Name: "author", Email: "author@example.com", When: time.Now()},
+		AllowEmptyCommits: true,
+	})
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "remote.git")
+	remote, err := git.PlainClone(path, true, &git.CloneOptions{URL: source})
+	assert.NoError(t, err)
+	return path, remote
+}
+
+func TestRewriter_backupAndRestore(t *testing.T) {
+	remotePath, remote := newRemoteRepo(t)
+	original, err := remote.Head()
+	assert.NoError(t, err)
+
+	backupDir := t.TempDir()
+	r := &Rewriter{gitCfg: configs.GitInfo{
+		RepoUrl:   remotePath,
+		Author:    "painter",
+		Email:     "painter@example.com",
+		Backup:    "both",
+		BackupDir: backupDir,
+	}}
+	paint := func() {
+		assert.NoError(t, r.openRepo())
+		dailyCommits := []dailyCommit{r.createCommit(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "Arbitrary commit #1")}
+		assert.NoError(t, r.commitToWorkTree(dailyCommits))
+		assert.NoError(t, r.backup(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+		assert.NoError(t, repo.ForcePush(r.repo, r.auth, ""))
+
+		painted, err := remote.Head()
+		assert.NoError(t, err)
+		assert.NotEqual(t, original.Hash(), painted.Hash())
+	}
+
+	// restored from the bundle file
+	paint()
+	assert.NoError(t, r.Restore(filepath.Join(backupDir, "painter-backup-20240102T030405Z.bundle"), ""))
+	restored, err := remote.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, original.Hash(), restored.Hash())
+
+	// restored from the backup ref on the remote
+	paint()
+	assert.NoError(t, r.Restore("", "20240102T030405Z"))
+	restored, err = remote.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, original.Hash(), restored.Hash())
+}
+
+func TestRewriter_backupAndRestore_NewBranch(t *testing.T) {
+	remotePath, remote := newRemoteRepo(t)
+
+	backupDir := t.TempDir()
+	r := &Rewriter{gitCfg: configs.GitInfo{
+		RepoUrl:   remotePath,
+		Author:    "painter",
+		Email:     "painter@example.com",
+		Branch:    "painting",
+		Backup:    "both",
+		BackupDir: backupDir,
+	}}
+	paint := func() {
+		assert.NoError(t, r.openRepo())
+		dailyCommits := []dailyCommit{r.createCommit(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "Arbitrary commit #1")}
+		assert.NoError(t, r.commitToWorkTree(dailyCommits))
+		assert.NoError(t, r.backup(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+		assert.NoError(t, repo.ForcePush(r.repo, r.auth, "painting"))
+
+		_, err := remote.Reference(plumbing.NewBranchReferenceName("painting"), false)
+		assert.NoError(t, err)
+	}
+
+	// deleted by the marker in the bundle file
+	paint()
+	assert.NoError(t, r.Restore(filepath.Join(backupDir, "painter-backup-20240102T030405Z.bundle"), ""))
+	_, err := remote.Reference(plumbing.NewBranchReferenceName("painting"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	// deleted by the marker of the backup ref on the remote
+	paint()
+	assert.NoError(t, r.Restore("", "20240102T030405Z"))
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("painting"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
@@ -0,0 +1,71 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/helper"
+	"fmt"
+	"time"
+)
+
+// getCalendar returns the start date after the leading columns and the date range of the calendar to paint,
+// the calendar of the year or the year from the start date if configured, otherwise the trailing year.
+// The first column is the week of the first day, days of the partial weeks out of the range are not painted.
+// The days are counted in the timezone.
+func getCalendar(cfg configs.Rewriter, now time.Time, loc *time.Location) (time.Time, graphql.DateRange, error) {
+	today := helper.Day(now, loc)
+	dateRange := graphql.DateRange{Location: loc}
+	switch {
+	case cfg.Year != 0 && cfg.StartDate != "":
+		return time.Time{}, dateRange, fmt.Errorf("year and start_date can't be both set")
+	case cfg.Year != 0:
+		dateRange.From = time.Date(cfg.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		dateRange.To = time.Date(cfg.Year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	case cfg.StartDate != "":
+		from, err := time.Parse(helper.DateFormat, cfg.StartDate)
+		if err != nil {
+			return time.Time{}, dateRange, fmt.Errorf("invalid start_date: %w", err)
+		}
+		dateRange.From, dateRange.To = from, from.AddDate(1, 0, -1)
+	default:
+		// the trailing year starts from the Sunday 52 weeks before the current week, like the calendar of GitHub
+		startDate, err := getStartSunday(today, cfg.LeadingColumns)
+		if err != nil {
+			return time.Time{}, dateRange, err
+		}
+		dateRange.From, dateRange.To = startDate.AddDate(0, 0, -7*cfg.LeadingColumns), today
+		return startDate, dateRange, nil
+	}
+
+	if dateRange.From.After(today) {
+		return time.Time{}, dateRange, fmt.Errorf("calendar starts in the future: %s", dateRange.From.Format(helper.DateFormat))
+	}
+	if dateRange.To.After(today) {
+		dateRange.To = today
+	}
+
+	startDate := getLatestSunday(dateRange.From).AddDate(0, 0, 7*cfg.LeadingColumns)
+	return startDate, dateRange, nil
+}
+
+// CalendarRange returns the date range of the calendar to paint
+func (r *Rewriter) CalendarRange() graphql.DateRange {
+	return r.calendarRange
+}
+
+// location returns the timezone the days are counted in, UTC if not configured
+func (r *Rewriter) location() *time.Location {
+	if r.loc == nil {
+		return time.UTC
+	}
+	return r.loc
+}
+
+// inCalendar tells if the day is shown in the calendar to paint, days of the partial weeks out of the range
+// belong to the calendars of the other years
+func (r *Rewriter) inCalendar(date time.Time) bool {
+	if r.calendarRange.IsZero() {
+		return true
+	}
+	return !date.Before(r.calendarRange.From) && !date.After(r.calendarRange.To)
+}
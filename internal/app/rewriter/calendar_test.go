@@ -0,0 +1,114 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/graphql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func Test_getCalendar(t *testing.T) {
+	now := time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)
+	trailingStart, err := getStartSunday(now, 2)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		cfg       configs.Rewriter
+		wantStart time.Time
+		wantRange graphql.DateRange
+		wantErr   string
+	}{
+		{
+			name:      "trailing year if no year is configured",
+			cfg:       configs.Rewriter{LeadingColumns: 2},
+			wantStart: trailingStart,
+			wantRange: graphql.DateRange{From: date(2022, 7, 3), To: date(2023, 7, 5), Location: time.UTC},
+		},
+		{
+			name:      "year starts from the week of January 1st",
+			cfg:       configs.Rewriter{Year: 2019, LeadingColumns: 2},
+			wantStart: date(2019, 1, 13),
+			wantRange: graphql.DateRange{From: date(2019, 1, 1), To: date(2019, 12, 31), Location: time.UTC},
+		},
+		{
+			name:      "current year ends today",
+			cfg:       configs.Rewriter{Year: 2023},
+			wantStart: date(2023, 1, 1),
+			wantRange: graphql.DateRange{From: date(2023, 1, 1), To: date(2023, 7, 5), Location: time.UTC},
+		},
+		{
+			name:      "start date starts from its week for a year",
+			cfg:       configs.Rewriter{StartDate: "2020-03-04"},
+			wantStart: date(2020, 3, 1),
+			wantRange: graphql.DateRange{From: date(2020, 3, 4), To: date(2021, 3, 3), Location: time.UTC},
+		},
+		{
+			name:    "future year should return error",
+			cfg:     configs.Rewriter{Year: 2024},
+			wantErr: "calendar starts in the future: 2024-01-01",
+		},
+		{
+			name:    "year and start date should return error",
+			cfg:     configs.Rewriter{Year: 2019, StartDate: "2020-03-04"},
+			wantErr: "year and start_date can't be both set",
+		},
+		{
+			name:    "invalid start date should return error",
+			cfg:     configs.Rewriter{StartDate: "2020/03/04"},
+			wantErr: "invalid start_date",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, dateRange, err := getCalendar(tt.cfg, now, time.UTC)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantRange, dateRange)
+		})
+	}
+}
+
+func TestRewriter_checkInCalendar(t *testing.T) {
+	// January 1st 2019 is a Tuesday, and December 31st is a Tuesday as well
+	r := &Rewriter{
+		startDate:     date(2018, 12, 30),
+		calendarRange: graphql.DateRange{From: date(2019, 1, 1), To: date(2019, 12, 31)},
+	}
+	column := domain.Letter{{1}, {1}, {1}, {1}, {1}, {1}, {1}}
+	assert.EqualError(t, r.checkInCalendar([]domain.Letter{column}),
+		"painting is clipped by the partial weeks of the calendar, dots out of it: 2018-12-30, 2018-12-31")
+
+	// the dots of the partial week are all in the calendar
+	bottom := domain.Letter{{0}, {0}, {0}, {1}, {1}, {1}, {1}}
+	assert.NoError(t, r.checkInCalendar([]domain.Letter{bottom}))
+
+	r.startDate = date(2019, 12, 29)
+	assert.EqualError(t, r.checkInCalendar([]domain.Letter{column}),
+		"painting is clipped by the partial weeks of the calendar, dots out of it: 2020-01-01, 2020-01-02, 2020-01-03, 2020-01-04")
+	top := domain.Letter{{1}, {1}, {1}, {0}, {0}, {0}, {0}}
+	assert.NoError(t, r.checkInCalendar([]domain.Letter{top}))
+}
+
+func TestRewriter_checkEndDate_year(t *testing.T) {
+	now := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	r := &Rewriter{calendarRange: graphql.DateRange{From: date(2019, 1, 1), To: date(2019, 12, 31)}}
+
+	// the last column is the week of December 29th
+	r.endDate = date(2020, 1, 5)
+	assert.NoError(t, r.checkEndDate(now))
+
+	r.endDate = date(2020, 1, 12)
+	assert.EqualError(t, r.checkEndDate(now), "end date is after the calendar, end date: 2020-01-12, last week: 2019-12-29")
+}
@@ -0,0 +1,232 @@
+package rewriter
+
+import (
+	"bytes"
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// snippetSeparator separates the snippets in the snippets file, like the fortune files
+const snippetSeparator = "%"
+
+// the default path and template of every content mode, the journal and the snippets are appended to the file,
+// the canvas overwrites it
+var contentDefaults = map[domain.Content]struct {
+	path     string
+	template string
+}{
+	domain.ContentJournal:  {path: "journal.md", template: "- {{.Time}} painted the {{.Layer}} of {{.Date}}\n"},
+	domain.ContentCanvas:   {path: "canvas.txt", template: "{{.Canvas}}\n#{{.Number}} at {{.Time}}\n"},
+	domain.ContentSnippets: {path: "snippets.md", template: "{{.Snippet}}\n"},
+}
+
+// contentWriter changes the content file in the work tree for every commit, so no commit is empty
+type contentWriter struct {
+	mode     domain.Content
+	path     string
+	tmpl     *template.Template
+	snippets []string
+	loc      *time.Location
+
+	// painted is the days painted so far with their layers, the foreground wins
+	painted map[time.Time]plan.Layer
+}
+
+// contentData is what the content template is rendered with
+type contentData struct {
+	Date    string
+	Time    string
+	Layer   plan.Layer
+	Number  int
+	Canvas  string
+	Snippet string
+}
+
+// newContentWriter returns the writer of the content mode, nil if the commits are empty
+func newContentWriter(c configs.Rewriter, loc *time.Location) (*contentWriter, error) {
+	mode := domain.Content(c.Content)
+	if mode == "" || mode == domain.ContentEmpty {
+		return nil, nil
+	}
+	defaults, ok := contentDefaults[mode]
+	if !ok {
+		return nil, fmt.Errorf("unknown content: %s", c.Content)
+	}
+
+	w := &contentWriter{mode: mode, path: c.ContentPath, loc: loc, painted: make(map[time.Time]plan.Layer)}
+	if w.path == "" {
+		w.path = defaults.path
+	}
+
+	text := c.ContentTemplate
+	if text == "" {
+		text = defaults.template
+	}
+	var err error
+	if w.tmpl, err = template.New("content").Parse(text); err != nil {
+		return nil, fmt.Errorf("invalid content template: %w", err)
+	}
+
+	if mode == domain.ContentSnippets {
+		if w.snippets, err = loadSnippets(c.SnippetsFile); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// loadSnippets reads the snippets separated by the lines of a single %
+func loadSnippets(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("snippets file is not configured")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read snippets file failed: %w", err)
+	}
+
+	var snippets []string
+	for _, snippet := range strings.Split("\n"+string(b)+"\n", "\n"+snippetSeparator+"\n") {
+		if snippet = strings.Trim(snippet, "\n"); snippet != "" {
+			snippets = append(snippets, snippet)
+		}
+	}
+	if len(snippets) == 0 {
+		return nil, fmt.Errorf("no snippet in %s", path)
+	}
+	return snippets, nil
+}
+
+// write renders the content of the commit and writes it to the content file in the work tree of the repo,
+// the file is staged to be committed, it fails if the file is unchanged as the commit would be empty
+func (w *contentWriter) write(r *git.Repository, worktree *git.Worktree, dc dailyCommit) error {
+	day := helper.Day(dc.date, w.loc)
+	if w.painted[day] != plan.LayerForeground {
+		w.painted[day] = plan.LayerBackground
+		if dc.layer == plan.LayerForeground {
+			w.painted[day] = plan.LayerForeground
+		}
+	}
+
+	data := contentData{
+		Date:   day.Format(helper.DateFormat),
+		Time:   dc.date.In(w.loc).Format(helper.DateTimeFormat),
+		Layer:  dc.layer,
+		Number: dc.number,
+	}
+	switch w.mode {
+	case domain.ContentCanvas:
+		data.Canvas = renderCanvas(w.painted)
+	case domain.ContentSnippets:
+		// the snippets are used in turn from the first commit
+		index := dc.number - 1
+		if index < 0 {
+			index = 0
+		}
+		data.Snippet = w.snippets[index%len(w.snippets)]
+	}
+	var content bytes.Buffer
+	if err := w.tmpl.Execute(&content, data); err != nil {
+		return fmt.Errorf("render content failed: %w", err)
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if w.mode == domain.ContentCanvas {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := worktree.Filesystem.OpenFile(w.path, flag, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s failed: %w", w.path, err)
+	}
+	if _, err = f.Write(content.Bytes()); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write %s failed: %w", w.path, err)
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	staged, err := worktree.Add(w.path)
+	if err != nil {
+		return fmt.Errorf("add %s failed: %w", w.path, err)
+	}
+	return w.checkChanged(r, staged)
+}
+
+// checkChanged fails if the staged content file is the same as in HEAD, e.g. the canvas of a template
+// not depending on the commit
+func (w *contentWriter) checkChanged(r *git.Repository, staged plumbing.Hash) error {
+	head, err := r.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// the first commit of an orphan branch
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get HEAD failed: %w", err)
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("get commit %s failed: %w", head.Hash(), err)
+	}
+
+	f, err := commit.File(w.path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get %s of HEAD failed: %w", w.path, err)
+	}
+	if f.Hash == staged {
+		return fmt.Errorf("%s is unchanged by the commit, the content template should change with every commit, "+
+			"e.g. with {{.Number}}", w.path)
+	}
+	return nil
+}
+
+// renderCanvas renders the painted days as a calendar of 7 rows from Sunday to Saturday, every column is a week
+// from the week of the first painted day, # is the foreground, + the background and . not painted yet
+func renderCanvas(painted map[time.Time]plan.Layer) string {
+	var first, last time.Time
+	for day := range painted {
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	if first.IsZero() {
+		return ""
+	}
+
+	first, last = getLatestSunday(first), getLatestSunday(last)
+	weeks := int(last.Sub(first).Hours())/24/7 + 1
+	rows := make([]string, domain.CalendarHeight)
+	for weekday := range rows {
+		row := make([]byte, weeks)
+		for week := range row {
+			switch painted[first.AddDate(0, 0, week*7+weekday)] {
+			case plan.LayerForeground:
+				row[week] = '#'
+			case plan.LayerBackground:
+				row[week] = '+'
+			default:
+				row[week] = '.'
+			}
+		}
+		rows[weekday] = string(row)
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,156 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/stat"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newContentWriter(t *testing.T) {
+	w, err := newContentWriter(configs.Rewriter{}, time.UTC)
+	assert.NoError(t, err)
+	assert.Nil(t, w, "commits are empty by default")
+
+	w, err = newContentWriter(configs.Rewriter{Content: "journal"}, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, "journal.md", w.path)
+
+	_, err = newContentWriter(configs.Rewriter{Content: "poems"}, time.UTC)
+	assert.EqualError(t, err, "unknown content: poems")
+	_, err = newContentWriter(configs.Rewriter{Content: "journal", ContentTemplate: "{{.Date"}, time.UTC)
+	assert.ErrorContains(t, err, "invalid content template")
+	_, err = newContentWriter(configs.Rewriter{Content: "snippets"}, time.UTC)
+	assert.EqualError(t, err, "snippets file is not configured")
+}
+
+func Test_loadSnippets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snippets.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("fmt.Println(1)\n%\nif ok {\n\n\treturn\n}\n%\n"), 0o644))
+
+	snippets, err := loadSnippets(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fmt.Println(1)", "if ok {\n\n\treturn\n}"}, snippets)
+}
+
+func Test_renderCanvas(t *testing.T) {
+	// Tuesday of the first week and Sunday of the third week
+	painted := map[time.Time]plan.Layer{
+		date(2023, 1, 3):  plan.LayerForeground,
+		date(2023, 1, 4):  plan.LayerBackground,
+		date(2023, 1, 15): plan.LayerBackground,
+	}
+	assert.Equal(t, "..+\n...\n#..\n+..\n...\n...\n...", renderCanvas(painted))
+	assert.Equal(t, "", renderCanvas(nil))
+}
+
+func TestRewriter_Apply_Content(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := []int{0, 0, 0}
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	var calendar []stat.CommitStat
+	for i, count := range counts {
+		calendar = append(calendar, stat.CommitStat{Date: start.AddDate(0, 0, i), Commits: count})
+	}
+	commits := map[plan.Layer][]stat.CommitStat{
+		plan.LayerBackground: {{Date: start, Commits: 1}, {Date: start.AddDate(0, 0, 1), Commits: 1}},
+		plan.LayerForeground: {{Date: start.AddDate(0, 0, 2), Commits: 2}},
+	}
+
+	snippets := filepath.Join(t.TempDir(), "snippets.txt")
+	assert.NoError(t, os.WriteFile(snippets, []byte("a := 1\n%\nb := 2\n"), 0o644))
+
+	tests := []struct {
+		name        string
+		cfg         configs.Rewriter
+		path        string
+		wantContent string
+	}{
+		{
+			name: "journal",
+			cfg:  configs.Rewriter{Content: "journal"},
+			path: "journal.md",
+			wantContent: "- 2023-01-01 00:00:00 painted the background of 2023-01-01\n" +
+				"- 2023-01-02 00:00:00 painted the background of 2023-01-02\n" +
+				"- 2023-01-03 00:00:00 painted the foreground of 2023-01-03\n" +
+				"- 2023-01-03 00:00:00 painted the foreground of 2023-01-03\n",
+		},
+		{
+			name:        "canvas",
+			cfg:         configs.Rewriter{Content: "canvas", ContentPath: "art/canvas.txt"},
+			path:        "art/canvas.txt",
+			wantContent: "+\n+\n#\n.\n.\n.\n.\n#4 at 2023-01-03 00:00:00\n",
+		},
+		{
+			name:        "snippets",
+			cfg:         configs.Rewriter{Content: "snippets", SnippetsFile: snippets, ContentTemplate: "{{.Number}}: {{.Snippet}}\n"},
+			path:        "snippets.md",
+			wantContent: "1: a := 1\n2: b := 2\n3: a := 1\n4: b := 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remotePath, remote := newRemoteRepo(t)
+			gitCfg := configs.GitInfo{RepoUrl: remotePath, Author: "painter", Email: "painter@example.com", Backup: "none"}
+			content, err := newContentWriter(tt.cfg, time.UTC)
+			assert.NoError(t, err)
+
+			r := &Rewriter{rewriterCfg: tt.cfg, gitCfg: gitCfg, stats: newMockStats(mockServer.URL), content: content}
+			assert.NoError(t, r.Apply(plan.New(r.config(), calendar, commits), false))
+
+			head, err := remote.Head()
+			assert.NoError(t, err)
+			iter, err := remote.Log(&git.LogOptions{From: head.Hash()})
+			assert.NoError(t, err)
+			painted := 0
+			assert.NoError(t, iter.ForEach(func(c *object.Commit) error {
+				if !strings.HasPrefix(c.Message, "Arbitrary commit") {
+					return nil
+				}
+				painted++
+				// every commit changes the content file
+				parent, err := c.Parent(0)
+				assert.NoError(t, err)
+				assert.NotEqual(t, parent.TreeHash, c.TreeHash, "commit %s is empty", c.Message)
+				return nil
+			}))
+			assert.Equal(t, 4, painted)
+
+			commit, err := remote.CommitObject(head.Hash())
+			assert.NoError(t, err)
+			f, err := commit.File(tt.path)
+			assert.NoError(t, err)
+			got, err := f.Contents()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContent, got)
+		})
+	}
+}
+
+func TestRewriter_Apply_ContentUnchanged(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockServer := newCalendarServer(start, []int{0})
+	defer mockServer.Close()
+
+	remotePath, _ := newRemoteRepo(t)
+	gitCfg := configs.GitInfo{RepoUrl: remotePath, Author: "painter", Email: "painter@example.com", Backup: "none"}
+	cfg := configs.Rewriter{Content: "canvas", ContentTemplate: "painted by the painter\n"}
+	content, err := newContentWriter(cfg, time.UTC)
+	assert.NoError(t, err)
+
+	// the second commit leaves the canvas as it is
+	r := &Rewriter{rewriterCfg: cfg, gitCfg: gitCfg, stats: newMockStats(mockServer.URL), content: content}
+	p := plan.New(r.config(), []stat.CommitStat{{Date: start}},
+		map[plan.Layer][]stat.CommitStat{plan.LayerBackground: {{Date: start, Commits: 2}}})
+	assert.ErrorContains(t, r.Apply(p, false), "canvas.txt is unchanged by the commit")
+}
@@ -0,0 +1,83 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/repo"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+)
+
+// painterMessage matches the messages of the commits created by createCommitByDay
+var painterMessage = regexp.MustCompile(`^Arbitrary commit #\d+$`)
+
+// EraseReport is what is removed by erasing a painting
+type EraseReport struct {
+	Commits int
+	// Days are the days of the removed commits in ascending order
+	Days []time.Time
+}
+
+// Erase rewrites the branch without the commits created by the painter and force pushes it, unless dry run,
+// the removed commits are reported before pushing
+func (r *Rewriter) Erase() (*EraseReport, error) {
+	if r.gitCfg.AppendOnly {
+		return nil, fmt.Errorf("erasing rewrites the branch, it can't be done in the append-only mode")
+	}
+
+	logrus.Info("preparing...")
+	if err := r.openRepo(); err != nil {
+		return nil, err
+	}
+
+	removed, err := repo.RewriteWithout(r.repo, r.gitCfg.Branch, isPainterCommit)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite branch failed: %w", err)
+	}
+
+	report := newEraseReport(removed, r.location())
+	if report.Commits == 0 {
+		logrus.Info("nothing to erase")
+		return report, nil
+	}
+	logrus.Infof("erasing %d commits on %d days, from %s to %s", report.Commits, len(report.Days),
+		report.Days[0].Format(helper.DateFormat), report.Days[len(report.Days)-1].Format(helper.DateFormat))
+
+	if !r.rewriterCfg.DryRun {
+		if err = r.forcePush(); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// isPainterCommit tells if the commit is created by the painter, by the trailers of a plan or by the message
+// of the commits created before the trailers
+func isPainterCommit(c *object.Commit) bool {
+	if _, ok := plan.ParseTrailers(c.Message)[plan.TrailerPlan]; ok {
+		return true
+	}
+	return painterMessage.MatchString(strings.TrimSpace(c.Message))
+}
+
+func newEraseReport(removed []*object.Commit, loc *time.Location) *EraseReport {
+	days := make(map[time.Time]bool)
+	for _, c := range removed {
+		days[helper.Day(c.Author.When, loc)] = true
+	}
+
+	report := &EraseReport{Commits: len(removed)}
+	for day := range days {
+		report.Days = append(report.Days, day)
+	}
+	sort.Slice(report.Days, func(i, j int) bool {
+		return report.Days[i].Before(report.Days[j])
+	})
+	return report
+}
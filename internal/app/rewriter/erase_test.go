@@ -0,0 +1,50 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_Erase(t *testing.T) {
+	remotePath, remote := newRemoteRepo(t)
+	gitCfg := configs.GitInfo{RepoUrl: remotePath, Author: "painter", Email: "painter@example.com", Backup: "none"}
+
+	// a painting with a commit of the user in between
+	painter := &Rewriter{gitCfg: gitCfg}
+	assert.NoError(t, painter.openRepo())
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, painter.commitToWorkTree([]dailyCommit{
+		painter.createCommit(day, "Arbitrary commit #1"),
+		painter.createCommit(day, "Arbitrary commit #2"),
+		painter.createCommit(day.AddDate(0, 0, 1), "fix typo"),
+		painter.createCommit(day.AddDate(0, 0, 3), "Arbitrary commit #3"),
+		painter.createCommit(day.AddDate(0, 0, 4), "painted\n\nPainter-Plan: 0123456789abcdef\n"),
+	}))
+	assert.NoError(t, painter.forcePush())
+
+	r := &Rewriter{gitCfg: gitCfg}
+	report, err := r.Erase()
+	assert.NoError(t, err)
+	assert.Equal(t, &EraseReport{Commits: 4, Days: []time.Time{day, day.AddDate(0, 0, 3), day.AddDate(0, 0, 4)}}, report)
+
+	head, err := remote.Head()
+	assert.NoError(t, err)
+	iter, err := remote.Log(&git.LogOptions{From: head.Hash()})
+	assert.NoError(t, err)
+	var messages []string
+	assert.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		messages = append(messages, c.Message)
+		return nil
+	}))
+	assert.Equal(t, []string{"fix typo", "initial commit"}, messages)
+
+	// nothing left to erase
+	report, err = r.Erase()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Commits)
+}
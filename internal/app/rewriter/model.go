@@ -1,6 +1,7 @@
 package rewriter
 
 import (
+	"contribution-painter/internal/pkg/plan"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -10,4 +11,13 @@ type dailyCommit struct {
 	date          time.Time
 	message       string
 	commitOptions *git.CommitOptions
+	// layer and number are rendered into the content of the commit in the content mode
+	layer  plan.Layer
+	number int
+}
+
+// paintDot is a filled dot of the painting, level is its colour level
+type paintDot struct {
+	date  time.Time
+	level uint
 }
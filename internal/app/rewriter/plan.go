@@ -0,0 +1,209 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/repo"
+	"contribution-painter/internal/pkg/stat"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Plan returns the plan of the painting against the current calendar, the background days are raised to the
+// background commits per day, then the painted dots to the commits per day of their levels
+func (r *Rewriter) Plan() (*plan.Plan, error) {
+	logrus.Info("planning...")
+	calendar, err := r.stats.CommitsByDay(r.calendarRange)
+	if err != nil {
+		return nil, fmt.Errorf("get contribution collection failed: %w", err)
+	}
+
+	letters, err := r.getLetters()
+	if err != nil {
+		return nil, fmt.Errorf("get letters failed: %w", err)
+	}
+
+	r.endDate = r.getEndDate()
+	logrus.Infof("painting date range: %s --- %s", r.startDate.Format(helper.DateFormat), r.endDate.Format(helper.DateFormat))
+	if err = r.checkEndDate(time.Now()); err != nil {
+		return nil, err
+	}
+	if err = r.checkInCalendar(letters); err != nil {
+		return nil, err
+	}
+
+	background := r.backgroundStats(calendar)
+	commitMap := make(map[time.Time]int)
+	for _, cs := range calendar {
+		commitMap[cs.Date] += cs.Commits
+	}
+	for _, cs := range background {
+		if cs.Commits > 0 {
+			commitMap[cs.Date] += cs.Commits
+		}
+	}
+	foreground := r.foregroundStats(letters, commitMap)
+
+	return plan.New(r.config(), calendar, map[plan.Layer][]stat.CommitStat{
+		plan.LayerBackground: background,
+		plan.LayerForeground: foreground,
+	}), nil
+}
+
+// Apply creates the commits of the plan with its author and pushes them, it refuses if the calendar
+// has drifted from the snapshot of the plan, or the plan is made from another config unless force is set.
+// The commits of the plan already in the branch are not created again, so a re-run only adds the missing ones
+func (r *Rewriter) Apply(p *plan.Plan, force bool) error {
+	if p.ConfigHash != plan.ConfigHash(r.config()) {
+		if !force {
+			return fmt.Errorf("the plan is made from another config, make a new plan or force applying it")
+		}
+		logrus.Warn("the plan is made from another config, applying the plan as it is")
+	}
+
+	stats, err := p.CommitStats()
+	if err != nil {
+		return fmt.Errorf("get commits of plan failed: %w", err)
+	}
+
+	if err = r.prepare(p); err != nil {
+		return fmt.Errorf("prepare repo failed: %w", err)
+	}
+
+	existing, err := r.existingCommits(p)
+	if err != nil {
+		return fmt.Errorf("get existing commits failed: %w", err)
+	}
+	stats = missingStats(stats, existing)
+
+	// the commits are authored by the identity of the plan
+	r.gitCfg.Author, r.gitCfg.Email = p.Author.Name, p.Author.Email
+	dailyCommits, err := r.createDailyCommits(p, stats)
+	if err != nil {
+		return fmt.Errorf("create daily commits failed: %w", err)
+	}
+
+	// Commit to working tree
+	if err = r.commitToWorkTree(dailyCommits); err != nil {
+		return fmt.Errorf("commit to work tree failed: %w", err)
+	}
+
+	switch {
+	case r.rewriterCfg.DryRun:
+		return nil
+	case r.gitCfg.AppendOnly:
+		return r.push()
+	default:
+		return r.forcePush()
+	}
+}
+
+// prepare checks the calendar against the snapshot of the plan and opens the repo, the tip of the remote
+// branch is recorded in the append-only mode to make sure it doesn't move until pushing
+func (r *Rewriter) prepare(p *plan.Plan) (err error) {
+	logrus.Info("preparing...")
+	if r.gitCfg.AppendOnly && r.gitCfg.Orphan {
+		return fmt.Errorf("orphan branch can't be painted in the append-only mode")
+	}
+
+	// the days of the snapshot are compared, whatever calendar the config is of now
+	snapshotRange, err := p.SnapshotRange()
+	if err != nil {
+		return err
+	}
+	snapshotRange.Location = r.location()
+	calendar, err := r.stats.CommitsByDay(snapshotRange)
+	if err != nil {
+		return fmt.Errorf("get contribution collection failed: %w", err)
+	}
+	if err = p.CheckDrift(calendar); err != nil {
+		return err
+	}
+
+	if err = r.openRepo(); err != nil {
+		return err
+	}
+
+	if r.gitCfg.AppendOnly {
+		r.remoteTip, err = repo.RemoteBranch(r.repo, r.auth, r.gitCfg.Branch)
+		if err != nil {
+			return fmt.Errorf("get remote branch failed: %w", err)
+		}
+		return r.fastForward()
+	}
+	return nil
+}
+
+// openRepo opens or clones the repo on disk if the local path is configured, otherwise clones it in memory
+func (r *Rewriter) openRepo() (err error) {
+	if err = r.newAuth(); err != nil {
+		return err
+	}
+
+	if r.gitCfg.LocalPath != "" {
+		r.repo, err = repo.OpenOrCloneRepo(r.gitCfg.LocalPath, r.gitCfg.RepoUrl, r.auth)
+	} else {
+		r.repo, err = repo.CloneRepo(r.gitCfg.RepoUrl, r.auth)
+	}
+	if err != nil {
+		return fmt.Errorf("clone repo failed: %w", err)
+	}
+
+	if r.gitCfg.Branch != "" {
+		if err = repo.CheckoutBranch(r.repo, r.gitCfg.Branch, r.gitCfg.Orphan); err != nil {
+			return fmt.Errorf("checkout branch %s failed: %w", r.gitCfg.Branch, err)
+		}
+	}
+
+	// the repo on disk may be left behind the remote by the commits pushed since the last run
+	if r.gitCfg.LocalPath != "" {
+		if err = repo.SyncBranch(r.repo, r.auth, r.gitCfg.Branch); err != nil {
+			return fmt.Errorf("sync branch failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Push pushes the repo on disk of the local path, e.g. after inspecting the commits of a dry run
+func (r *Rewriter) Push() error {
+	if r.gitCfg.LocalPath == "" {
+		return fmt.Errorf("local path is not configured")
+	}
+
+	if err := r.openRepo(); err != nil {
+		return err
+	}
+	if !r.gitCfg.AppendOnly {
+		return r.forcePush()
+	}
+
+	// the painting is on top of the remote branch as it was last fetched
+	var err error
+	if r.remoteTip, err = repo.TrackedBranch(r.repo, r.gitCfg.Branch); err != nil {
+		return fmt.Errorf("get remote branch failed: %w", err)
+	}
+	if err = r.fastForward(); err != nil {
+		return err
+	}
+	return r.push()
+}
+
+// newAuth chooses the auth method by the scheme of the repo url
+func (r *Rewriter) newAuth() (err error) {
+	r.auth, err = repo.NewAuthMethod(r.gitCfg.RepoUrl, repo.Credentials{
+		Token:            r.gitCfg.GhToken,
+		SSHKeyFile:       r.gitCfg.SSHKeyFile,
+		SSHKeyPassphrase: r.gitCfg.SSHKeyPassphrase,
+	})
+	if err != nil {
+		return fmt.Errorf("get auth method failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Rewriter) config() configs.Configuration {
+	return configs.Configuration{GitInfo: r.gitCfg, Rewriter: r.rewriterCfg}
+}
@@ -0,0 +1,88 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/stat"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_Plan(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := make([]int, 42)
+	counts[0], counts[3], counts[40] = 2, 20, 7
+
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	r := &Rewriter{
+		rewriterCfg: configs.Rewriter{TargetLetters: ".", BackgroundCommitsPerDay: 1, ForegroundCommitsPerDay: 9},
+		gitCfg:      configs.GitInfo{Author: "painter", Email: "painter@example.com", GhToken: "secret"},
+		startDate:   start.AddDate(0, 0, 7),
+		stats:       newMockStats(mockServer.URL),
+		dict:        dict.NewDictionary(domain.Font75),
+	}
+
+	p, err := r.Plan()
+	assert.NoError(t, err)
+
+	// the period is painted from the second week for 5 weeks, the dot is at the bottom of the 3rd column
+	want := make(map[string]map[plan.Layer]int)
+	for i := 7; i < len(counts); i++ {
+		if i != 40 {
+			want[start.AddDate(0, 0, i).Format(helper.DateFormat)] = map[plan.Layer]int{plan.LayerBackground: 1}
+		}
+	}
+	want[start.AddDate(0, 0, 7+2*7+6).Format(helper.DateFormat)][plan.LayerForeground] = 8
+
+	assert.Equal(t, want, p.Commits)
+	assert.Len(t, p.Calendar, len(counts))
+	assert.Equal(t, 20, p.Calendar["2023-01-04"])
+	assert.Equal(t, "painter", p.Author.Name)
+
+	// the calendar has changed since the snapshot
+	p.Calendar["2023-01-04"] = 19
+	assert.ErrorContains(t, r.Apply(p, false), "calendar has drifted from the snapshot, 2023-01-04: 19 -> 20")
+}
+
+func TestRewriter_Apply_AnotherConfig(t *testing.T) {
+	start := date(2019, 1, 1)
+	calendar := []stat.CommitStat{{Date: start}, {Date: start.AddDate(0, 0, 1)}, {Date: start.AddDate(0, 0, 2)}}
+	commits := map[plan.Layer][]stat.CommitStat{plan.LayerBackground: {{Date: start, Commits: 1}}}
+	gitCfg := configs.GitInfo{Author: "painter", Email: "painter@example.com"}
+	p := plan.New(configs.Configuration{GitInfo: gitCfg, Rewriter: configs.Rewriter{Year: 2019}}, calendar, commits)
+
+	// a commit has been made on the second day of the snapshot since
+	var queries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		queries = append(queries, string(body))
+		_, _ = writer.Write([]byte(`{"data": {"user": {"contributionsCollection": {"contributionCalendar": {
+			"weeks": [{"contributionDays": [{"date": "2019-01-02", "contributionCount": 1}]}]}}}}}`))
+	}))
+	defer mockServer.Close()
+
+	r := &Rewriter{
+		rewriterCfg:   configs.Rewriter{Year: 2020},
+		gitCfg:        gitCfg,
+		calendarRange: graphql.DateRange{From: date(2020, 1, 1), To: date(2020, 12, 31)},
+		stats:         newMockStats(mockServer.URL),
+	}
+	assert.EqualError(t, r.Apply(p, false), "the plan is made from another config, make a new plan or force applying it")
+	assert.Empty(t, queries)
+
+	// forced, the days of the snapshot are still compared
+	assert.ErrorContains(t, r.Apply(p, true), "calendar has drifted from the snapshot, 2019-01-02: 0 -> 1")
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], `from: \"2019-01-01T00:00:00Z\", to: \"2019-01-03T23:59:59Z\"`)
+}
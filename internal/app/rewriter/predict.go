@@ -0,0 +1,45 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/stat"
+	"fmt"
+	"time"
+)
+
+// PredictCalendar returns the commits by day of the calendar once the painting is pushed, the painted days
+// are raised to the commits per day of their levels and the other days keep their existing commits
+func (r *Rewriter) PredictCalendar() ([]stat.CommitStat, error) {
+	existing, err := r.stats.CommitsByDay(r.calendarRange)
+	if err != nil {
+		return nil, fmt.Errorf("get commits by day failed: %w", err)
+	}
+
+	letters, err := r.getLetters()
+	if err != nil {
+		return nil, fmt.Errorf("get letters failed: %w", err)
+	}
+	r.endDate = r.getEndDate()
+	if err = r.checkInCalendar(letters); err != nil {
+		return nil, err
+	}
+
+	planned := make(map[time.Time]int)
+	for _, cs := range existing {
+		if cs.Date.Before(r.startDate) || cs.Date.After(r.endDate) || !r.inCalendar(cs.Date) {
+			continue
+		}
+		planned[cs.Date] = r.rewriterCfg.BackgroundCommitsPerDay
+	}
+	for _, dot := range r.paintDots(letters) {
+		planned[dot.date] = r.foregroundCommits(dot.level)
+	}
+
+	predicted := make([]stat.CommitStat, len(existing))
+	for i, cs := range existing {
+		if planned[cs.Date] > cs.Commits {
+			cs.Commits = planned[cs.Date]
+		}
+		predicted[i] = cs
+	}
+	return predicted, nil
+}
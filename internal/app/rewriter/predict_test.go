@@ -0,0 +1,44 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"contribution-painter/internal/pkg/stat"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_PredictCalendar(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := make([]int, 42)
+	counts[0], counts[3], counts[40] = 2, 20, 7
+
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	r := &Rewriter{
+		rewriterCfg: configs.Rewriter{TargetLetters: ".", BackgroundCommitsPerDay: 1, ForegroundCommitsPerDay: 9},
+		startDate:   start.AddDate(0, 0, 7),
+		stats:       newMockStats(mockServer.URL),
+		dict:        dict.NewDictionary(domain.Font75),
+	}
+
+	got, err := r.PredictCalendar()
+	assert.NoError(t, err)
+
+	want := make([]stat.CommitStat, len(counts))
+	for i := range want {
+		want[i] = stat.CommitStat{Date: start.AddDate(0, 0, i), Commits: counts[i]}
+	}
+	// the period is painted from the second week for 5 weeks, the dot is at the bottom of the 3rd column
+	for i := 7; i < len(want); i++ {
+		want[i].Commits = 1
+	}
+	want[7+2*7+6].Commits = 9
+	want[40].Commits = 7
+
+	assert.Equal(t, want, got)
+}
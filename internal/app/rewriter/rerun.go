@@ -0,0 +1,74 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+)
+
+// existingCommits returns the commits of the plan already in the checked out branch by day and layer,
+// told by the trailers of the plan
+func (r *Rewriter) existingCommits(p *plan.Plan) (map[string]map[plan.Layer]int, error) {
+	existing := make(map[string]map[plan.Layer]int)
+
+	// HEAD of an empty repo or an orphan branch is not resolved before the first commit
+	head, err := r.repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return existing, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	iter, err := r.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		trailers := plan.ParseTrailers(c.Message)
+		if trailers[plan.TrailerPlan] != p.ID {
+			return nil
+		}
+
+		day, layer := trailers[plan.TrailerDate], plan.Layer(trailers[plan.TrailerLayer])
+		if existing[day] == nil {
+			existing[day] = make(map[plan.Layer]int)
+		}
+		existing[day][layer]++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+	return existing, nil
+}
+
+// missingStats returns the commits of the plan which are not in the repo yet, so applying the same plan again
+// converges to the painting instead of stacking the commits
+func missingStats(stats []plan.LayerStat, existing map[string]map[plan.Layer]int) []plan.LayerStat {
+	var missing []plan.LayerStat
+	done := 0
+	for _, ls := range stats {
+		exist := existing[ls.Date.Format(helper.DateFormat)][ls.Layer]
+		if exist > ls.Commits {
+			exist = ls.Commits
+		}
+		done += exist
+
+		ls.Commits -= exist
+		if ls.Commits > 0 {
+			missing = append(missing, ls)
+		}
+	}
+
+	if done > 0 {
+		logrus.Infof("%d commits of the plan already exist, skipping them", done)
+	}
+	return missing
+}
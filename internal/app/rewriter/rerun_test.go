@@ -0,0 +1,122 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/stat"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_Apply_Rerun(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := []int{0, 1, 0}
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	var calendar []stat.CommitStat
+	for i, count := range counts {
+		calendar = append(calendar, stat.CommitStat{Date: start.AddDate(0, 0, i), Commits: count})
+	}
+	remotePath, remote := newRemoteRepo(t)
+	gitCfg := configs.GitInfo{RepoUrl: remotePath, Author: "painter", Email: "painter@example.com", Backup: "none"}
+	commits := map[plan.Layer][]stat.CommitStat{
+		plan.LayerBackground: {{Date: start, Commits: 1}, {Date: start.AddDate(0, 0, 2), Commits: 1}},
+		plan.LayerForeground: {{Date: start.AddDate(0, 0, 2), Commits: 2}},
+	}
+
+	painted := func() map[string]int {
+		head, err := remote.Head()
+		assert.NoError(t, err)
+		iter, err := remote.Log(&git.LogOptions{From: head.Hash()})
+		assert.NoError(t, err)
+		layers := make(map[string]int)
+		assert.NoError(t, iter.ForEach(func(c *object.Commit) error {
+			trailers := plan.ParseTrailers(c.Message)
+			layers[trailers[plan.TrailerDate]+" "+trailers[plan.TrailerLayer]]++
+			return nil
+		}))
+		return layers
+	}
+	want := map[string]int{
+		" ":                     1, // the initial commit
+		"2023-01-01 background": 1,
+		"2023-01-03 background": 1,
+		"2023-01-03 foreground": 2,
+	}
+
+	p := plan.New(configs.Configuration{GitInfo: gitCfg}, calendar, commits)
+	r := &Rewriter{gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+	assert.NoError(t, r.Apply(p, false))
+	assert.Equal(t, want, painted())
+
+	// the calendar hasn't caught up yet, the same painting is planned again
+	p = plan.New(configs.Configuration{GitInfo: gitCfg}, calendar, commits)
+	r = &Rewriter{gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+	assert.NoError(t, r.Apply(p, false))
+	assert.Equal(t, want, painted())
+
+	// the commit times and the branch are changed, the painting is the same
+	r = &Rewriter{rewriterCfg: configs.Rewriter{TimeDistribution: "random", TimeSeed: 42}, gitCfg: gitCfg,
+		stats: newMockStats(mockServer.URL)}
+	r.gitCfg.Branch = "master"
+	p = plan.New(r.config(), calendar, commits)
+	assert.NoError(t, r.Apply(p, false))
+	assert.Equal(t, want, painted())
+
+	// a commit more is planned on a day, only the missing one is added
+	commits[plan.LayerForeground][0].Commits = 3
+	p = plan.New(configs.Configuration{GitInfo: gitCfg}, calendar, commits)
+	r = &Rewriter{gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+	assert.NoError(t, r.Apply(p, false))
+	want["2023-01-03 foreground"] = 3
+	assert.Equal(t, want, painted())
+}
+
+func TestRewriter_Apply_RerunOrphan(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockServer := newCalendarServer(start, []int{0})
+	defer mockServer.Close()
+
+	remotePath, remote := newRemoteRepo(t)
+	gitCfg := configs.GitInfo{RepoUrl: remotePath, Author: "painter", Email: "painter@example.com", Backup: "none",
+		Branch: "painting", Orphan: true}
+	calendar := []stat.CommitStat{{Date: start}}
+	commits := map[plan.Layer][]stat.CommitStat{plan.LayerBackground: {{Date: start, Commits: 2}}}
+
+	branch := func() *object.Commit {
+		ref, err := remote.Reference(plumbing.NewBranchReferenceName("painting"), false)
+		assert.NoError(t, err)
+		c, err := remote.CommitObject(ref.Hash())
+		assert.NoError(t, err)
+		return c
+	}
+
+	// the commit times differ by run, a new root would not be the same commits
+	times := configs.Rewriter{TimeDistribution: "random", TimeSeed: 1}
+	r := &Rewriter{rewriterCfg: times, gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+	assert.NoError(t, r.Apply(plan.New(r.config(), calendar, commits), false))
+	painted := branch()
+
+	// the orphan branch on the remote is painted on again, without a new root or any commit
+	times.TimeSeed = 2
+	r = &Rewriter{rewriterCfg: times, gitCfg: gitCfg, stats: newMockStats(mockServer.URL)}
+	assert.NoError(t, r.Apply(plan.New(r.config(), calendar, commits), false))
+	assert.Equal(t, painted.Hash, branch().Hash)
+
+	iter, err := remote.Log(&git.LogOptions{From: painted.Hash})
+	assert.NoError(t, err)
+	roots := 0
+	assert.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		if c.NumParents() == 0 {
+			roots++
+		}
+		return nil
+	}))
+	assert.Equal(t, 1, roots)
+}
@@ -6,14 +6,18 @@ import (
 	"contribution-painter/internal/pkg/dict"
 	"contribution-painter/internal/pkg/graphql"
 	"contribution-painter/internal/pkg/helper"
-	"contribution-painter/internal/pkg/repo"
+	"contribution-painter/internal/pkg/plan"
 	"contribution-painter/internal/pkg/stat"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,10 +25,17 @@ type Rewriter struct {
 	rewriterCfg configs.Rewriter
 	gitCfg      configs.GitInfo
 
-	repo         *git.Repository
-	startDate    time.Time
-	endDate      time.Time
-	currentState []stat.CommitStat
+	repo      *git.Repository
+	auth      transport.AuthMethod
+	remoteTip *plumbing.Reference
+	startDate time.Time
+	endDate   time.Time
+	// calendarRange is the calendar of the configured year or the trailing year, in the timezone
+	calendarRange graphql.DateRange
+	// loc is the timezone the days are counted in
+	loc *time.Location
+	// content changes a file for every commit in the content mode, nil if the commits are empty
+	content *contentWriter
 
 	stats *stat.ContributionStats
 	dict  domain.Dictionary
@@ -33,88 +44,116 @@ type Rewriter struct {
 func NewRewriter(cfg configs.Configuration) *Rewriter {
 	ghGraphql := graphql.NewGhGraphql(cfg.GitInfo)
 
-	startDate, err := getStartSunday(time.Now(), cfg.Rewriter.LeadingColumns)
+	loc, err := time.LoadLocation(cfg.Rewriter.Timezone)
 	if err != nil {
-		logrus.Fatalf("Get first Saturday failed: %v", err)
+		logrus.Fatalf("Invalid timezone: %v", err)
 	}
 
-	return &Rewriter{
-		rewriterCfg: cfg.Rewriter,
-		gitCfg:      cfg.GitInfo,
-		startDate:   startDate,
-		stats:       stat.NewContributionStats(ghGraphql),
-		dict:        dict.NewDictionary(domain.Font(cfg.Rewriter.Font)),
+	startDate, calendarRange, err := getCalendar(cfg.Rewriter, time.Now(), loc)
+	if err != nil {
+		logrus.Fatalf("Get calendar failed: %v", err)
 	}
-}
 
-func (r *Rewriter) getEndDate() time.Time {
-	c := r.rewriterCfg
-	letters, err := r.dict.GetLetters(c.TargetLetters, c.LetterSpacing, c.LeadingColumns, c.TrailingColumns)
-	if err != nil {
-		logrus.Fatalf("Get letters failed: %v", err)
+	if err = validateLevels(cfg.Rewriter); err != nil {
+		logrus.Fatalf("Invalid levels: %v", err)
 	}
 
-	width := 0
-	for _, letter := range letters {
-		width += len(letter[0])
+	if err = validateTimes(cfg.Rewriter); err != nil {
+		logrus.Fatalf("Invalid commit times: %v", err)
 	}
 
-	endDate := r.startDate.Add(time.Duration(width) * 24 * 7 * time.Hour)
-	logrus.Infof("letter width: %d, end date: %s", width, endDate.Format(helper.DateFormat))
+	content, err := newContentWriter(cfg.Rewriter, loc)
+	if err != nil {
+		logrus.Fatalf("Invalid content: %v", err)
+	}
 
-	return endDate
+	return &Rewriter{
+		rewriterCfg:   cfg.Rewriter,
+		gitCfg:        cfg.GitInfo,
+		startDate:     startDate,
+		calendarRange: calendarRange,
+		loc:           loc,
+		content:       content,
+		stats:         stat.NewContributionStats(ghGraphql),
+		dict:          newDictionary(cfg.Rewriter),
+	}
 }
 
-func (r *Rewriter) Run() error {
-	err := r.prepare()
+// newDictionary creates the dictionary of the font file if configured, otherwise of the registered font
+func newDictionary(cfg configs.Rewriter) domain.Dictionary {
+	opts, err := dictionaryOptions(cfg)
 	if err != nil {
-		return fmt.Errorf("prepare repo failed: %w", err)
+		logrus.Fatalf("Invalid font options: %v", err)
 	}
 
-	err = r.printCommitStat()
-	if err != nil {
-		return fmt.Errorf("print commit stat failed: %w", err)
+	if cfg.FontFile == "" {
+		return dict.NewDictionary(domain.Font(cfg.Font), opts...)
 	}
 
-	_, err = r.drawBackground()
+	d, err := dict.NewDictionaryFromFile(cfg.FontFile, opts...)
 	if err != nil {
-		return fmt.Errorf("draw backgroud failed: %w", err)
+		logrus.Fatalf("Load font file failed: %v", err)
 	}
+	return d
+}
 
-	err = r.drawForeground()
-	if err != nil {
-		return fmt.Errorf("draw foreground failed: %w", err)
+func dictionaryOptions(cfg configs.Rewriter) ([]dict.Option, error) {
+	var opts []dict.Option
+	if cfg.Proportional {
+		opts = append(opts, dict.WithProportional())
 	}
 
-	if !r.rewriterCfg.DryRun {
-		err = repo.ForcePush(r.repo, r.gitCfg.GhToken)
-		if err != nil {
-			return fmt.Errorf("force push failed: %w", err)
+	if len(cfg.Kerning) > 0 {
+		kerning := make(map[domain.KerningPair]int)
+		for _, k := range cfg.Kerning {
+			pair := []rune(k.Pair)
+			if len(pair) != 2 {
+				return nil, fmt.Errorf("kerning pair should be 2 letters: %q", k.Pair)
+			}
+			kerning[domain.KerningPair{pair[0], pair[1]}] = k.Adjust
 		}
-		logrus.Info("force push success")
+		opts = append(opts, dict.WithKerning(kerning))
 	}
 
-	return nil
+	return opts, nil
 }
 
-func (r *Rewriter) prepare() (err error) {
-	logrus.Info("preparing...")
-	r.repo, err = repo.CloneRepo(r.gitCfg.RepoUrl, r.gitCfg.GhToken)
+func (r *Rewriter) getEndDate() time.Time {
+	letters, err := r.getLetters()
 	if err != nil {
-		logrus.Fatalf("Clone repo failed: %v", err)
+		logrus.Fatalf("Get letters failed: %v", err)
+	}
+
+	// the start date is already after the leading columns
+	width := 0
+	for _, letter := range letters[r.rewriterCfg.LeadingColumns:] {
+		width += len(letter[0])
 	}
 
-	currentState, err := r.stats.CommitsByDay()
+	endDate := r.startDate.Add(time.Duration(width) * 24 * 7 * time.Hour)
+	logrus.Infof("letter width: %d, end date: %s", width, endDate.Format(helper.DateFormat))
+
+	return endDate
+}
+
+func (r *Rewriter) Run() error {
+	err := r.printCommitStat()
+	if err != nil {
+		return fmt.Errorf("print commit stat failed: %w", err)
+	}
+
+	p, err := r.Plan()
 	if err != nil {
-		return fmt.Errorf("get contribution collection failed: %w", err)
+		return fmt.Errorf("plan failed: %w", err)
 	}
-	r.currentState = currentState
 
-	r.endDate = r.getEndDate()
-	logrus.Infof("painting date range: %s --- %s", r.startDate.Format(helper.DateFormat), r.endDate.Format(helper.DateFormat))
+	return r.Apply(p, false)
+}
 
-	//weeks between start and end date
-	now := time.Now()
+// checkEndDate fails if the painting doesn't end before the current week, unless today is Saturday,
+// or doesn't end in the last week of the calendar of the configured year
+func (r *Rewriter) checkEndDate(now time.Time) error {
+	now = helper.Day(now, r.location())
 	latestSunday := getLatestSunday(now)
 	if now.Weekday() != time.Saturday {
 		latestSunday = latestSunday.AddDate(0, 0, -7)
@@ -124,21 +163,40 @@ func (r *Rewriter) prepare() (err error) {
 			r.endDate.Format(helper.DateFormat), latestSunday.Format(helper.DateFormat))
 	}
 
+	// the end date is the Sunday after the last column
+	if !r.calendarRange.IsZero() {
+		lastSunday := getLatestSunday(r.calendarRange.To)
+		if r.endDate.AddDate(0, 0, -7).After(lastSunday) {
+			return fmt.Errorf("end date is after the calendar, end date: %s, last week: %s",
+				r.endDate.Format(helper.DateFormat), lastSunday.Format(helper.DateFormat))
+		}
+	}
+
 	return nil
 }
 
-func (r *Rewriter) drawBackground() ([]stat.CommitStat, error) {
-	logrus.Info("drawing background...")
-
-	// Compute the number of commits by days between the start date and today
-	commitStats, err := r.stats.CommitsByDay()
-	if err != nil {
-		return nil, fmt.Errorf("get commits by day failed: %w", err)
+// checkInCalendar fails if any filled dot is in the partial weeks out of the calendar of the configured year,
+// as the painting would be clipped there
+func (r *Rewriter) checkInCalendar(letters []domain.Letter) error {
+	var clipped []string
+	for _, dot := range r.paintDots(letters) {
+		if !r.inCalendar(dot.date) {
+			clipped = append(clipped, dot.date.Format(helper.DateFormat))
+		}
+	}
+	if len(clipped) > 0 {
+		return fmt.Errorf("painting is clipped by the partial weeks of the calendar, dots out of it: %s",
+			strings.Join(clipped, ", "))
 	}
+	return nil
+}
 
+// backgroundStats returns the commits needed by every day between the start and the end date
+// to reach the background commits per day
+func (r *Rewriter) backgroundStats(calendar []stat.CommitStat) []stat.CommitStat {
 	var commitStatsInDateRange []stat.CommitStat
-	for _, cs := range commitStats {
-		if cs.Date.Before(r.startDate) || cs.Date.After(r.endDate) {
+	for _, cs := range calendar {
+		if cs.Date.Before(r.startDate) || cs.Date.After(r.endDate) || !r.inCalendar(cs.Date) {
 			continue
 		}
 
@@ -150,94 +208,55 @@ func (r *Rewriter) drawBackground() ([]stat.CommitStat, error) {
 		commitStatsInDateRange = append(commitStatsInDateRange, cs)
 	}
 
-	dailyCommits, err := r.createDailyCommits(commitStatsInDateRange)
-	if err != nil {
-		return nil, fmt.Errorf("create daily commits failed: %w", err)
-	}
-
-	// Commit to working tree
-	err = r.commitToWorkTree(dailyCommits)
-	if err != nil {
-		return nil, fmt.Errorf("commit to work tree failed: %w", err)
-	}
-
-	return commitStatsInDateRange, nil
+	return commitStatsInDateRange
 }
 
-func (r *Rewriter) drawForeground() error {
-	logrus.Info("drawing foreground...")
-	c := r.rewriterCfg
-	letters, err := r.dict.GetLetters(c.TargetLetters, c.LetterSpacing, c.LeadingColumns, c.TrailingColumns)
-	if err != nil {
-		logrus.Fatalf("Get letters failed: %v", err)
+// foregroundStats returns the commits needed by every filled dot to reach the commits per day of its level
+func (r *Rewriter) foregroundStats(letters []domain.Letter, commitMap map[time.Time]int) []stat.CommitStat {
+	var stats []stat.CommitStat
+	for _, dot := range r.paintDots(letters) {
+		stats = append(stats, stat.CommitStat{
+			Date:    dot.date,
+			Commits: r.foregroundCommits(dot.level) - commitMap[dot.date],
+		})
 	}
 
-	// group commits by date
-	commits, err := repo.GetCommits(r.repo, &git.LogOptions{})
-	if err != nil {
-		return fmt.Errorf("get commits failed: %w", err)
-	}
-	commitMap := make(map[time.Time]int)
-	for _, commit := range commits {
-		commitMap[commit.Author.When.Truncate(24*time.Hour)]++
-	}
-	existedCommitStats, err := r.stats.CommitsByDay()
-	if err != nil {
-		return fmt.Errorf("get existed commits failed: %w", err)
-	}
-	for _, cs := range existedCommitStats {
-		if _, ok := commitMap[cs.Date]; !ok {
-			commitMap[cs.Date] = cs.Commits
-		} else {
-			commitMap[cs.Date] += cs.Commits
-		}
-	}
+	return stats
+}
 
-	var stats []stat.CommitStat
+// paintDots walks the letters column by column, every column is a week starting from the start date,
+// and returns the filled dots with their days
+func (r *Rewriter) paintDots(letters []domain.Letter) []paintDot {
+	var dots []paintDot
 	lettersWithoutLeadingAndTrailingColumns := letters[r.rewriterCfg.LeadingColumns : len(letters)-r.rewriterCfg.TrailingColumns]
 	dataCursor := r.startDate
 	for _, letter := range lettersWithoutLeadingAndTrailingColumns { // every letter
-		if len(letter[0]) != r.dict.FontWidth() {
-			dataCursor = dataCursor.AddDate(0, 0, 7)
-			continue
-		}
-
-		for i := 0; i < r.dict.FontWidth(); i++ { // every column, 0 - 5
-			weekStart, weekEnd := 0, 7
-			if len(letter) < 7 {
-				dataCursor = dataCursor.Add(24 * time.Hour)
-				weekStart = 1
-				weekEnd = 6
-			}
-
-			for j := weekStart; j < weekEnd; j++ { // every row, 0 - 6
-				if letter[j][i] == 1 {
-					stats = append(stats, stat.CommitStat{
-						Date:    dataCursor,
-						Commits: r.rewriterCfg.ForegroundCommitsPerDay - commitMap[dataCursor],
-					})
+		// letters lower than the calendar are vertically centered
+		topSpace := (domain.CalendarHeight - len(letter)) / 2
+		for i := 0; i < len(letter[0]); i++ { // every column, a glyph has its own width
+			for j := 0; j < domain.CalendarHeight; j++ { // every row, 0 - 6
+				row := j - topSpace
+				if row >= 0 && row < len(letter) && letter[row][i] > 0 {
+					dots = append(dots, paintDot{date: dataCursor, level: letter[row][i]})
 				}
 				dataCursor = dataCursor.Add(24 * time.Hour)
 			}
-
-			if len(letter) < 7 {
-				dataCursor = dataCursor.Add(24 * time.Hour)
-			}
 		}
 	}
 
-	dailyCommits, err := r.createDailyCommits(stats)
-	if err != nil {
-		return fmt.Errorf("create daily commits failed: %w", err)
-	}
+	return dots
+}
 
-	// Commit to working tree
-	err = r.commitToWorkTree(dailyCommits)
-	if err != nil {
-		return fmt.Errorf("commit to work tree failed: %w", err)
+// foregroundCommits returns the commits per day of a dot in the given level from the commits per level,
+// if not configured, the max level is the foreground and lower levels are interpolated between the
+// background and the foreground
+func (r *Rewriter) foregroundCommits(level uint) int {
+	if len(r.rewriterCfg.CommitsPerLevel) == domain.MaxLevel {
+		return r.rewriterCfg.CommitsPerLevel[level-1]
 	}
 
-	return nil
+	bg, fg := r.rewriterCfg.BackgroundCommitsPerDay, r.rewriterCfg.ForegroundCommitsPerDay
+	return bg + (fg-bg)*int(level)/domain.MaxLevel
 }
 
 // commitToWorkTree commits dailyCommits to work tree
@@ -249,12 +268,18 @@ func (r *Rewriter) commitToWorkTree(dailyCommits []dailyCommit) error {
 
 	infoToPrint := make(map[time.Time]int)
 	for _, dc := range dailyCommits {
+		if r.content != nil {
+			if err = r.content.write(r.repo, worktree, dc); err != nil {
+				return fmt.Errorf("write content failed: %w", err)
+			}
+		}
+
 		_, err = worktree.Commit(dc.message, dc.commitOptions)
 		if err != nil {
 			return fmt.Errorf("commit failed: %w", err)
 		}
 
-		infoToPrint[dc.date.Truncate(24*time.Hour)]++
+		infoToPrint[helper.Day(dc.date, r.location())]++
 	}
 
 	// Print commit info order by date asc
@@ -272,34 +297,59 @@ func (r *Rewriter) commitToWorkTree(dailyCommits []dailyCommit) error {
 	return nil
 }
 
-func (r *Rewriter) createDailyCommits(commitStatsInDateRange []stat.CommitStat) ([]dailyCommit, error) {
+// createDailyCommits creates the commits of the plan by day and layer, every commit is tagged with the trailers
+// of the plan, the times are spread over the days by the time distribution with the time seed
+func (r *Rewriter) createDailyCommits(p *plan.Plan, stats []plan.LayerStat) ([]dailyCommit, error) {
 	msgCount := 0
+	rng := rand.New(rand.NewSource(r.rewriterCfg.TimeSeed))
 
 	var dailyCommits []dailyCommit
-	for _, cs := range commitStatsInDateRange {
-		if cs.Commits <= 0 {
-			continue
+	for start := 0; start < len(stats); {
+		// the times of a day are drawn once for all its layers, so the commits are in time order
+		end, commits := start, 0
+		for ; end < len(stats) && stats[end].Date.Equal(stats[start].Date); end++ {
+			if stats[end].Commits > 0 {
+				commits += stats[end].Commits
+			}
 		}
+		times := r.commitTimes(rng, stats[start].Date, commits)
 
-		dc := r.createCommitByDay(cs, &msgCount)
-		dailyCommits = append(dailyCommits, dc...)
+		for _, ls := range stats[start:end] {
+			if ls.Commits <= 0 {
+				continue
+			}
+
+			dc := r.createCommitByDay(ls, times[:ls.Commits], p.Trailers(ls.Date, ls.Layer), &msgCount)
+			dailyCommits = append(dailyCommits, dc...)
+			times = times[ls.Commits:]
+		}
+		start = end
 	}
 
 	logrus.Infof("create %d daily commits", msgCount)
 	return dailyCommits, nil
 }
 
-func (r *Rewriter) createCommitByDay(cs stat.CommitStat, globalCount *int) []dailyCommit {
+func (r *Rewriter) createCommitByDay(ls plan.LayerStat, times []time.Time, trailers string, globalCount *int) []dailyCommit {
 	var dailyCommits []dailyCommit
-	for i := 0; i < cs.Commits; i++ {
+	for i := 0; i < ls.Commits; i++ {
 		*globalCount++
-		dailyCommits = append(dailyCommits, r.createCommit(cs.Date, fmt.Sprintf("Arbitrary commit #%d", *globalCount)))
+		msg := fmt.Sprintf("Arbitrary commit #%d\n\n%s", *globalCount, trailers)
+		dc := r.createCommitAt(times[i], msg)
+		dc.layer, dc.number = ls.Layer, *globalCount
+		dailyCommits = append(dailyCommits, dc)
 	}
 
 	return dailyCommits
 }
 
+// createCommit creates a commit on the day, at the start of the day in the timezone
 func (r *Rewriter) createCommit(date time.Time, commitMsg string) dailyCommit {
+	return r.createCommitAt(helper.InLocation(date, r.location()), commitMsg)
+}
+
+// createCommitAt creates a commit at the time
+func (r *Rewriter) createCommitAt(date time.Time, commitMsg string) dailyCommit {
 	return dailyCommit{
 		date:    date,
 		message: commitMsg,
@@ -314,7 +364,7 @@ func (r *Rewriter) createCommit(date time.Time, commitMsg string) dailyCommit {
 				Email: r.gitCfg.Email,
 				When:  date,
 			},
-			AllowEmptyCommits: true, // Create an empty commit
+			AllowEmptyCommits: r.content == nil, // Create an empty commit unless in the content mode
 		},
 	}
 }
@@ -1,9 +1,14 @@
 package rewriter
 
 import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/stat"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_getSunday(t *testing.T) {
@@ -90,3 +95,65 @@ func Test_latestSunday(t *testing.T) {
 		})
 	}
 }
+
+func TestRewriter_foregroundStats(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time {
+		return start.AddDate(0, 0, n)
+	}
+
+	r := &Rewriter{
+		rewriterCfg: configs.Rewriter{BackgroundCommitsPerDay: 2, ForegroundCommitsPerDay: 10, LeadingColumns: 1, TrailingColumns: 1},
+		startDate:   start,
+	}
+	space := domain.Letter{{0}, {0}, {0}, {0}, {0}}
+	letters := []domain.Letter{
+		space,
+		{{4, 4}, {0, 0}, {0, 0}, {0, 0}, {0, 2}}, // 2 columns wide, centered in the week
+		space,
+		{{4}, {4}, {4}, {4}, {4}}, // 1 column wide
+		space,
+	}
+
+	got := r.foregroundStats(letters, map[time.Time]int{day(1): 3})
+
+	assert.Equal(t, []stat.CommitStat{
+		{Date: day(1), Commits: 7},
+		{Date: day(8), Commits: 10},
+		{Date: day(12), Commits: 6},
+		{Date: day(22), Commits: 10},
+		{Date: day(23), Commits: 10},
+		{Date: day(24), Commits: 10},
+		{Date: day(25), Commits: 10},
+		{Date: day(26), Commits: 10},
+	}, got)
+}
+
+func TestRewriter_foregroundCommits(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  configs.Rewriter
+		want []int
+	}{
+		{
+			name: "levels should be interpolated between background and foreground",
+			cfg:  configs.Rewriter{BackgroundCommitsPerDay: 2, ForegroundCommitsPerDay: 10},
+			want: []int{4, 6, 8, 10},
+		},
+		{
+			name: "commits per level should take precedence",
+			cfg:  configs.Rewriter{BackgroundCommitsPerDay: 2, ForegroundCommitsPerDay: 10, CommitsPerLevel: []int{3, 7, 12, 20}},
+			want: []int{3, 7, 12, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rewriter{rewriterCfg: tt.cfg}
+			var got []int
+			for level := uint(1); level <= domain.MaxLevel; level++ {
+				got = append(got, r.foregroundCommits(level))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
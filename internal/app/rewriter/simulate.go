@@ -0,0 +1,42 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/simulate"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Simulate prints the letters laid onto the calendar of the trailing or the configured year to w, the same way
+// they are painted, it fails if the letters don't fit before the current week or in the calendar, including
+// its partial weeks
+func (r *Rewriter) Simulate(w io.Writer) error {
+	letters, err := r.getLetters()
+	if err != nil {
+		return fmt.Errorf("get letters failed: %w", err)
+	}
+
+	height := 0
+	for _, letter := range letters {
+		if len(letter) > height {
+			height = len(letter)
+		}
+	}
+
+	// the start date is after the leading columns, the calendar starts 52 weeks before the current week
+	// or in the first week of the configured year
+	calendarStart := r.startDate.AddDate(0, 0, -7*r.rewriterCfg.LeadingColumns)
+	c := r.rewriterCfg
+	s := simulate.NewCalendarSimulator(w, calendarStart, r.dict, c.LetterSpacing, c.LeadingColumns, c.TrailingColumns,
+		(domain.CalendarHeight-height)/2)
+	if err = s.SimulateLetters(letters); err != nil {
+		return err
+	}
+
+	r.endDate = r.getEndDate()
+	if err = r.checkEndDate(time.Now()); err != nil {
+		return err
+	}
+	return r.checkInCalendar(letters)
+}
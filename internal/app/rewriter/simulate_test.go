@@ -0,0 +1,55 @@
+package rewriter
+
+import (
+	"bytes"
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_Simulate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     configs.Rewriter
+		wantErr string
+	}{
+		{
+			name: "letters fitting the calendar should be printed",
+			cfg:  configs.Rewriter{TargetLetters: "HI", LetterSpacing: 1, LeadingColumns: 2},
+		},
+		{
+			name:    "letters longer than the calendar should return error",
+			cfg:     configs.Rewriter{TargetLetters: "HELLO WORLD", LetterSpacing: 1},
+			wantErr: "letters are too long: 61, 53",
+		},
+		{
+			name:    "letters reaching the current week should return error",
+			cfg:     configs.Rewriter{TargetLetters: "HELLO WOR", LetterSpacing: 1, TrailingColumns: 4},
+			wantErr: "end date is after now",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startDate, err := getStartSunday(time.Now(), tt.cfg.LeadingColumns)
+			assert.NoError(t, err)
+			r := &Rewriter{rewriterCfg: tt.cfg, startDate: startDate, dict: dict.NewDictionary(domain.Font75)}
+
+			var buf bytes.Buffer
+			err = r.Simulate(&buf)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			assert.Len(t, lines, domain.CalendarHeight+1)
+			assert.True(t, strings.HasPrefix(lines[1], "    ▢ ▢ █ ▢ ▢ ▢ █ ▢ ▢ █ █ █ ▢ ▢"), lines[1])
+		})
+	}
+}
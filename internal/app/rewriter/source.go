@@ -0,0 +1,111 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/pixel"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// getLetters returns the letters to paint from the configured source, every filled dot carries its colour level
+func (r *Rewriter) getLetters() ([]domain.Letter, error) {
+	c := r.rewriterCfg
+	switch domain.Source(c.Source) {
+	case "", domain.SourceLetters:
+		return r.getTargetLetters()
+	case domain.SourceImage:
+		picture, err := pixel.LoadImage(c.ImageFile)
+		if err != nil {
+			return nil, fmt.Errorf("load image failed: %w", err)
+		}
+		return padColumns(picture, c.LeadingColumns, c.TrailingColumns), nil
+	case domain.SourceCanvas:
+		canvas, err := pixel.LoadCanvas(c.CanvasFile)
+		if err != nil {
+			return nil, fmt.Errorf("load canvas failed: %w", err)
+		}
+		weeks := len(canvas[0])
+		logrus.Infof("canvas %s: %d weeks, from %s to %s", c.CanvasFile, weeks,
+			r.startDate.Format(helper.DateFormat), r.startDate.AddDate(0, 0, weeks*7-1).Format(helper.DateFormat))
+		return padColumns(canvas, c.LeadingColumns, c.TrailingColumns), nil
+	default:
+		return nil, fmt.Errorf("unknown source: %s", c.Source)
+	}
+}
+
+// getTargetLetters returns the letters of the target letters after applying the case policy,
+// the glyphs are painted in the foreground level with an optional drop shadow
+func (r *Rewriter) getTargetLetters() ([]domain.Letter, error) {
+	c := r.rewriterCfg
+	target, err := domain.Case(c.Case).Apply(c.TargetLetters)
+	if err != nil {
+		return nil, err
+	}
+
+	letters, err := r.dict.GetLetters(target, c.LetterSpacing, c.LeadingColumns, c.TrailingColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	level := uint(domain.MaxLevel)
+	if c.ForegroundLevel > 0 {
+		level = uint(c.ForegroundLevel)
+	}
+	for i, letter := range letters {
+		letters[i] = letter.WithLevel(level)
+	}
+
+	if c.ShadowLevel > 0 {
+		// the shadow is cast across letters, split the text into columns to keep one letter per leading
+		// and trailing column
+		letters = domain.Letters(letters).Compose().WithShadow(uint(c.ShadowLevel)).Columns()
+	}
+	return letters, nil
+}
+
+// padColumns surrounds the picture with leading and trailing blank columns
+func padColumns(picture domain.Letter, leading, trailing int) []domain.Letter {
+	space := make(domain.Letter, len(picture))
+	for i := range space {
+		space[i] = []uint{0}
+	}
+
+	var letters []domain.Letter
+	for i := 0; i < leading; i++ {
+		letters = append(letters, space)
+	}
+	letters = append(letters, picture)
+	for i := 0; i < trailing; i++ {
+		letters = append(letters, space)
+	}
+	return letters
+}
+
+// validateLevels checks the levels and the commits per level are in the range of the calendar colours
+func validateLevels(c configs.Rewriter) error {
+	if c.ForegroundLevel < 0 || c.ForegroundLevel > domain.MaxLevel {
+		return fmt.Errorf("foreground level %d is not in [0, %d]", c.ForegroundLevel, domain.MaxLevel)
+	}
+	if c.ShadowLevel < 0 || c.ShadowLevel > domain.MaxLevel {
+		return fmt.Errorf("shadow level %d is not in [0, %d]", c.ShadowLevel, domain.MaxLevel)
+	}
+
+	if len(c.CommitsPerLevel) == 0 {
+		return nil
+	}
+	if len(c.CommitsPerLevel) != domain.MaxLevel {
+		return fmt.Errorf("commits per level should have %d values for level 1 - %d, got %d",
+			domain.MaxLevel, domain.MaxLevel, len(c.CommitsPerLevel))
+	}
+	previous := c.BackgroundCommitsPerDay
+	for i, commits := range c.CommitsPerLevel {
+		if commits < previous {
+			return fmt.Errorf("commits of level %d should not be less than %d, got %d", i+1, previous, commits)
+		}
+		previous = commits
+	}
+	return nil
+}
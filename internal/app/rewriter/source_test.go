@@ -0,0 +1,138 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_getLetters(t *testing.T) {
+	imageFile := filepath.Join(t.TempDir(), "dot.png")
+	img := image.NewGray(image.Rect(0, 0, 1, 7))
+	for y := 0; y < 7; y++ {
+		img.SetGray(0, y, color.Gray{Y: 0xff})
+	}
+	img.SetGray(0, 3, color.Gray{Y: 0})
+	f, err := os.Create(imageFile)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(f, img))
+	assert.NoError(t, f.Close())
+
+	canvasFile := filepath.Join(t.TempDir(), "canvas.txt")
+	assert.NoError(t, os.WriteFile(canvasFile, []byte("#.\n.1\n..\n..\n..\n..\n.#\n"), 0o644))
+
+	space := domain.Letter{{0}, {0}, {0}, {0}, {0}, {0}, {0}}
+	tests := []struct {
+		name    string
+		cfg     configs.Rewriter
+		want    []domain.Letter
+		wantErr bool
+	}{
+		{
+			name: "letters should be painted in the foreground level",
+			cfg:  configs.Rewriter{TargetLetters: "i", Case: "upper", LetterSpacing: 1, LeadingColumns: 1},
+			want: []domain.Letter{space, dict.L75I.WithLevel(domain.MaxLevel)},
+		},
+		{
+			name: "letters should be painted in the foreground level with a shadow",
+			cfg:  configs.Rewriter{TargetLetters: "-", LeadingColumns: 1, TrailingColumns: 1, ForegroundLevel: 3, ShadowLevel: 1},
+			want: domain.Letter{
+				{0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0},
+				{0, 3, 3, 3, 3, 3, 0},
+				{0, 0, 1, 1, 1, 1, 1},
+				{0, 0, 0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0, 0},
+			}.Columns(),
+		},
+		{
+			name: "image should be surrounded by blank columns",
+			cfg:  configs.Rewriter{Source: "image", ImageFile: imageFile, LeadingColumns: 1, TrailingColumns: 2},
+			want: []domain.Letter{space, {{0}, {0}, {0}, {4}, {0}, {0}, {0}}, space, space},
+		},
+		{
+			name: "canvas should be surrounded by blank columns",
+			cfg:  configs.Rewriter{Source: "canvas", CanvasFile: canvasFile, TrailingColumns: 1},
+			want: []domain.Letter{{{4, 0}, {0, 1}, {0, 0}, {0, 0}, {0, 0}, {0, 0}, {0, 4}}, space},
+		},
+		{
+			name:    "unknown source should return error",
+			cfg:     configs.Rewriter{Source: "video"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rewriter{rewriterCfg: tt.cfg, dict: dict.NewDictionary(domain.Font75)}
+			got, err := r.getLetters()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validateLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     configs.Rewriter
+		wantErr string
+	}{
+		{
+			name: "default levels should be valid",
+			cfg:  configs.Rewriter{BackgroundCommitsPerDay: 1, ForegroundCommitsPerDay: 10},
+		},
+		{
+			name: "commits per level should be valid",
+			cfg:  configs.Rewriter{BackgroundCommitsPerDay: 1, CommitsPerLevel: []int{1, 5, 5, 9}, ForegroundLevel: 3, ShadowLevel: 1},
+		},
+		{
+			name:    "foreground level out of range should return error",
+			cfg:     configs.Rewriter{ForegroundLevel: 5},
+			wantErr: "foreground level 5 is not in [0, 4]",
+		},
+		{
+			name:    "shadow level out of range should return error",
+			cfg:     configs.Rewriter{ShadowLevel: -1},
+			wantErr: "shadow level -1 is not in [0, 4]",
+		},
+		{
+			name:    "commits per level of a wrong length should return error",
+			cfg:     configs.Rewriter{CommitsPerLevel: []int{1, 2, 3}},
+			wantErr: "commits per level should have 4 values for level 1 - 4, got 3",
+		},
+		{
+			name:    "decreasing commits per level should return error",
+			cfg:     configs.Rewriter{BackgroundCommitsPerDay: 2, CommitsPerLevel: []int{3, 4, 3, 5}},
+			wantErr: "commits of level 3 should not be less than 4, got 3",
+		},
+		{
+			name:    "commits per level less than background should return error",
+			cfg:     configs.Rewriter{BackgroundCommitsPerDay: 2, CommitsPerLevel: []int{1, 4, 5, 6}},
+			wantErr: "commits of level 1 should not be less than 2, got 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLevels(tt.cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
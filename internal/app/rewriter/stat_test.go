@@ -1,66 +1,25 @@
 package rewriter
 
 import (
-	"contribution-painter/internal/pkg/graphql"
-	"encoding/json"
-	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRewriter_printCommitStat(t *testing.T) {
-	resp := &graphql.ContributionsCollectionResp{}
-	err := jsonModelFromFilePath("mocks/contributions_collection_resp.json", resp)
+	mockResp, err := os.ReadFile("mocks/contributions_collection_resp.json")
 	assert.NoError(t, err)
 
-	groupByColor := make(map[string][]graphql.ContributionDay)
-	for _, week := range resp.Data.User.ContributionsCollection.ContributionCalendar.Weeks {
-		for _, day := range week.ContributionDays {
-			groupByColor[day.Color] = append(groupByColor[day.Color], day)
-		}
-	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write(mockResp)
+	}))
+	defer mockServer.Close()
 
-	var stats contributionStats
-	for color, days := range groupByColor {
-		stats = append(stats, contributionStat{color: color, contributionDays: days})
-	}
+	r := &Rewriter{stats: newMockStats(mockServer.URL)}
 
-	// Sort the stats
-	sort.Sort(stats)
-	for _, stat := range stats {
-		// min, max, mean, median
-		min := contributionDays(stat.contributionDays).min()
-		max := contributionDays(stat.contributionDays).max()
-		mean := contributionDays(stat.contributionDays).mean()
-		median := contributionDays(stat.contributionDays).median()
-
-		// Print the stats
-		t.Logf(printFormat, colorToHuman[stat.color], stat.color, len(stat.contributionDays), min, max, mean, median)
-	}
-}
-
-func jsonModelFromFilePath(file string, result interface{}) error {
-	// Open the file
-	jsonFile, err := os.Open(file)
-	if err != nil {
-		return err
-	}
-	defer jsonFile.Close()
-
-	// Read the file
-	byteValue, err := io.ReadAll(jsonFile)
-	if err != nil {
-		return err
-	}
-
-	// Unmarshal the JSON
-	err = json.Unmarshal(byteValue, &result)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	assert.NoError(t, r.printCommitStat())
 }
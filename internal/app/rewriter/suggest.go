@@ -0,0 +1,52 @@
+package rewriter
+
+import (
+	"contribution-painter/internal/pkg/stat"
+	"fmt"
+	"time"
+)
+
+// SuggestCommitsPerLevel predicts the colours of the calendar after painting and returns the minimal
+// background commits per day and commits per level 1 - 4 that keep every day in its intended colour.
+// The background is level 0 if the painting range has no commit yet, otherwise it is level 1.
+func (r *Rewriter) SuggestCommitsPerLevel() (int, []int, error) {
+	existing, err := r.stats.CommitsByDay(r.calendarRange)
+	if err != nil {
+		return 0, nil, fmt.Errorf("get commits by day failed: %w", err)
+	}
+
+	letters, err := r.getLetters()
+	if err != nil {
+		return 0, nil, fmt.Errorf("get letters failed: %w", err)
+	}
+	r.endDate = r.getEndDate()
+	if err = r.checkInCalendar(letters); err != nil {
+		return 0, nil, err
+	}
+
+	bgLevel := 0
+	var background []time.Time
+	for _, cs := range existing {
+		if cs.Date.Before(r.startDate) || cs.Date.After(r.endDate) || !r.inCalendar(cs.Date) {
+			continue
+		}
+		background = append(background, cs.Date)
+		if cs.Commits > 0 {
+			bgLevel = 1
+		}
+	}
+
+	targets := make(map[time.Time]int)
+	for _, date := range background {
+		targets[date] = bgLevel
+	}
+	for _, dot := range r.paintDots(letters) {
+		targets[dot.date] = int(dot.level)
+	}
+
+	commits, err := stat.SuggestCommitsPerLevel(existing, targets)
+	if err != nil {
+		return 0, nil, fmt.Errorf("suggest commits per level failed: %w", err)
+	}
+	return commits[bgLevel], commits[1:], nil
+}
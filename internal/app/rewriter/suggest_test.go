@@ -0,0 +1,65 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/stat"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriter_SuggestCommitsPerLevel(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := make([]int, 35)
+	counts[0] = 2
+
+	mockServer := newCalendarServer(start, counts)
+	defer mockServer.Close()
+
+	r := &Rewriter{
+		rewriterCfg: configs.Rewriter{TargetLetters: "-"},
+		startDate:   start,
+		stats:       newMockStats(mockServer.URL),
+		dict:        dict.NewDictionary(domain.Font75),
+	}
+
+	background, perLevel, err := r.SuggestCommitsPerLevel()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, background)
+	assert.Equal(t, []int{2, 3, 4, 5}, perLevel)
+}
+
+// newCalendarServer serves a contribution calendar of the daily counts from the start date
+func newCalendarServer(start time.Time, counts []int) *httptest.Server {
+	var days []string
+	for i, count := range counts {
+		days = append(days, fmt.Sprintf(`{"date": "%s", "contributionCount": %d, "color": ""}`,
+			start.AddDate(0, 0, i).Format(helper.DateFormat), count))
+	}
+	resp := fmt.Sprintf(`{"data": {"user": {"contributionsCollection": {"contributionCalendar": {
+		"weeks": [{"contributionDays": [%s]}]}}}}}`, strings.Join(days, ","))
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(resp))
+	}))
+}
+
+func newMockStats(url string) *stat.ContributionStats {
+	return stat.NewContributionStats(&graphql.GhGraphql{
+		C: &graphql.GraphClient{
+			Url:    url,
+			Client: &http.Client{},
+		},
+	})
+}
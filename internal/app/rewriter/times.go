@@ -0,0 +1,120 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/helper"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const hoursPerDay = 24
+
+// defaultWorkingHours are the working hours [9:00, 18:00) if not configured
+var defaultWorkingHours = []int{9, 18}
+
+// validateTimes checks the time distribution and its options
+func validateTimes(c configs.Rewriter) error {
+	switch domain.TimeDistribution(c.TimeDistribution) {
+	case "", domain.TimesStart, domain.TimesRandom:
+		return nil
+	case domain.TimesWorkingHours:
+		_, _, err := workingHours(c)
+		return err
+	case domain.TimesHistogram:
+		if len(c.TimeHistogram) != hoursPerDay {
+			return fmt.Errorf("time histogram should have %d values for the hours 0 - 23, got %d",
+				hoursPerDay, len(c.TimeHistogram))
+		}
+		total := 0
+		for hour, weight := range c.TimeHistogram {
+			if weight < 0 {
+				return fmt.Errorf("weight of hour %d should not be negative, got %d", hour, weight)
+			}
+			total += weight
+		}
+		if total == 0 {
+			return fmt.Errorf("time histogram should have a positive weight")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown time distribution: %s", c.TimeDistribution)
+	}
+}
+
+// workingHours returns the start and the end hour of the working hours
+func workingHours(c configs.Rewriter) (int, int, error) {
+	hours := c.WorkingHours
+	if len(hours) == 0 {
+		hours = defaultWorkingHours
+	}
+	if len(hours) != 2 || hours[0] < 0 || hours[0] >= hours[1] || hours[1] > hoursPerDay {
+		return 0, 0, fmt.Errorf("working hours should be [start, end) within 0 - %d, got %v", hoursPerDay, hours)
+	}
+	return hours[0], hours[1], nil
+}
+
+// commitTimes returns the times of the commits on the day in ascending order by the time distribution,
+// every time is within the day in the timezone, whose length may be changed by a DST transition
+func (r *Rewriter) commitTimes(rng *rand.Rand, day time.Time, commits int) []time.Time {
+	loc := r.location()
+	dayStart, dayEnd := helper.InLocation(day, loc), helper.InLocation(day.AddDate(0, 0, 1), loc)
+	y, m, d := day.Date()
+
+	// between returns a random time in [from, to)
+	between := func(from, to time.Time) time.Time {
+		if !to.After(from) {
+			return from
+		}
+		return from.Add(time.Duration(rng.Int63n(int64(to.Sub(from)))))
+	}
+
+	times := make([]time.Time, commits)
+	for i := range times {
+		t := dayStart
+		switch domain.TimeDistribution(r.rewriterCfg.TimeDistribution) {
+		case domain.TimesWorkingHours:
+			from, to, _ := workingHours(r.rewriterCfg)
+			t = between(time.Date(y, m, d, from, 0, 0, 0, loc), time.Date(y, m, d, to, 0, 0, 0, loc))
+		case domain.TimesRandom:
+			t = between(dayStart, dayEnd)
+		case domain.TimesHistogram:
+			hour := pickHour(rng, r.rewriterCfg.TimeHistogram)
+			t = between(time.Date(y, m, d, hour, 0, 0, 0, loc), time.Date(y, m, d, hour+1, 0, 0, 0, loc))
+		}
+
+		// the wall clock hours skipped or repeated by a DST transition may fall out of the day
+		t = t.Truncate(time.Second)
+		if t.Before(dayStart) {
+			t = dayStart
+		}
+		if !t.Before(dayEnd) {
+			t = dayEnd.Add(-time.Second)
+		}
+		times[i] = t
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	return times
+}
+
+// pickHour returns an hour at random by the weights of the histogram
+func pickHour(rng *rand.Rand, histogram []int) int {
+	total := 0
+	for _, weight := range histogram {
+		total += weight
+	}
+
+	n := rng.Intn(total)
+	for hour, weight := range histogram {
+		if n < weight {
+			return hour
+		}
+		n -= weight
+	}
+	return len(histogram) - 1
+}
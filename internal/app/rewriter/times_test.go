@@ -0,0 +1,141 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/plan"
+	"contribution-painter/internal/pkg/stat"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateTimes(t *testing.T) {
+	histogram := make([]int, 24)
+	tests := []struct {
+		name    string
+		cfg     configs.Rewriter
+		wantErr string
+	}{
+		{name: "start of the day by default", cfg: configs.Rewriter{}},
+		{name: "random", cfg: configs.Rewriter{TimeDistribution: "random", TimeSeed: 42}},
+		{name: "default working hours", cfg: configs.Rewriter{TimeDistribution: "working_hours"}},
+		{
+			name:    "invalid working hours",
+			cfg:     configs.Rewriter{TimeDistribution: "working_hours", WorkingHours: []int{18, 9}},
+			wantErr: "working hours should be [start, end) within 0 - 24, got [18 9]",
+		},
+		{
+			name:    "histogram of the wrong length",
+			cfg:     configs.Rewriter{TimeDistribution: "histogram", TimeHistogram: []int{1, 2}},
+			wantErr: "time histogram should have 24 values for the hours 0 - 23, got 2",
+		},
+		{
+			name:    "histogram without weight",
+			cfg:     configs.Rewriter{TimeDistribution: "histogram", TimeHistogram: histogram},
+			wantErr: "time histogram should have a positive weight",
+		},
+		{
+			name:    "unknown distribution",
+			cfg:     configs.Rewriter{TimeDistribution: "weekends"},
+			wantErr: "unknown time distribution: weekends",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTimes(tt.cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRewriter_commitTimes(t *testing.T) {
+	night := make([]int, 24)
+	night[23], night[0] = 3, 1
+
+	days := []struct {
+		location string
+		day      time.Time
+	}{
+		{location: "UTC", day: date(2023, 7, 5)},
+		{location: "America/New_York", day: date(2023, 3, 12)}, // 23 hours
+		{location: "America/New_York", day: date(2023, 11, 5)}, // 25 hours
+		{location: "America/Santiago", day: date(2022, 9, 11)}, // starts at 01:00
+		{location: "Asia/Kathmandu", day: date(2023, 7, 5)},    // +05:45
+	}
+	tests := []struct {
+		name      string
+		cfg       configs.Rewriter
+		wantHours func(hour int) bool
+	}{
+		{
+			name:      "start of the day",
+			cfg:       configs.Rewriter{},
+			wantHours: func(hour int) bool { return hour <= 1 },
+		},
+		{
+			name:      "working hours",
+			cfg:       configs.Rewriter{TimeDistribution: string(domain.TimesWorkingHours), WorkingHours: []int{10, 16}},
+			wantHours: func(hour int) bool { return hour >= 10 && hour < 16 },
+		},
+		{
+			name:      "random",
+			cfg:       configs.Rewriter{TimeDistribution: string(domain.TimesRandom), TimeSeed: 7},
+			wantHours: func(hour int) bool { return true },
+		},
+		{
+			name:      "histogram",
+			cfg:       configs.Rewriter{TimeDistribution: string(domain.TimesHistogram), TimeHistogram: night},
+			wantHours: func(hour int) bool { return hour == 23 || hour <= 1 },
+		},
+	}
+	for _, tt := range tests {
+		for _, d := range days {
+			t.Run(tt.name+" "+d.location, func(t *testing.T) {
+				loc := mustLoadLocation(t, d.location)
+				r := &Rewriter{rewriterCfg: tt.cfg, loc: loc}
+
+				times := r.commitTimes(rand.New(rand.NewSource(tt.cfg.TimeSeed)), d.day, 200)
+				assert.Len(t, times, 200)
+				assert.True(t, sort.SliceIsSorted(times, func(i, j int) bool { return times[i].Before(times[j]) }))
+				for _, when := range times {
+					assert.Equal(t, d.day, helper.Day(when, loc), "commit at %s is out of the day", when)
+					assert.True(t, tt.wantHours(when.In(loc).Hour()), "commit at %s is out of the hours", when)
+				}
+
+				// the same seed gives the same times
+				again := r.commitTimes(rand.New(rand.NewSource(tt.cfg.TimeSeed)), d.day, 200)
+				assert.Equal(t, times, again)
+			})
+		}
+	}
+}
+
+func TestRewriter_createDailyCommits_times(t *testing.T) {
+	r := &Rewriter{rewriterCfg: configs.Rewriter{TimeDistribution: "random", TimeSeed: 42}}
+	p := plan.New(configs.Configuration{}, nil, nil)
+	stats := []plan.LayerStat{
+		{CommitStat: stat.CommitStat{Date: date(2023, 1, 1), Commits: 5}, Layer: plan.LayerBackground},
+		{CommitStat: stat.CommitStat{Date: date(2023, 1, 1), Commits: 5}, Layer: plan.LayerForeground},
+		{CommitStat: stat.CommitStat{Date: date(2023, 1, 2), Commits: 3}, Layer: plan.LayerForeground},
+	}
+
+	dailyCommits, err := r.createDailyCommits(p, stats)
+	assert.NoError(t, err)
+	assert.Len(t, dailyCommits, 13)
+	// both layers of a day are in time order, the background comes first
+	for i := 1; i < len(dailyCommits); i++ {
+		assert.False(t, dailyCommits[i].date.Before(dailyCommits[i-1].date), "commit #%d is before #%d", i+1, i)
+	}
+	assert.Equal(t, plan.LayerBackground, dailyCommits[4].layer)
+	assert.Equal(t, plan.LayerForeground, dailyCommits[5].layer)
+	assert.Equal(t, date(2023, 1, 2), helper.Day(dailyCommits[10].date, time.UTC))
+}
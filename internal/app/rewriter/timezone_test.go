@@ -0,0 +1,105 @@
+package rewriter
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/helper"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func Test_getCalendar_timezone(t *testing.T) {
+	// it's still the last day of 2022 in Los Angeles
+	now := time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	_, _, err := getCalendar(configs.Rewriter{Year: 2023}, now, mustLoadLocation(t, "America/Los_Angeles"))
+	assert.EqualError(t, err, "calendar starts in the future: 2023-01-01")
+
+	_, dateRange, err := getCalendar(configs.Rewriter{Year: 2023}, now, mustLoadLocation(t, "Asia/Tokyo"))
+	assert.NoError(t, err)
+	assert.Equal(t, date(2023, 1, 1), dateRange.To)
+}
+
+func Test_getCalendar_trailingYearTimezone(t *testing.T) {
+	// it's already Thursday in Tokyo
+	now := time.Date(2023, 7, 5, 20, 0, 0, 0, time.UTC)
+	loc := mustLoadLocation(t, "Asia/Tokyo")
+	_, dateRange, err := getCalendar(configs.Rewriter{}, now, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, graphql.DateRange{From: date(2022, 7, 3), To: date(2023, 7, 6), Location: loc}, dateRange)
+
+	// the trailing year of 53 weeks is queried in two windows
+	var queries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		queries = append(queries, string(body))
+		_, _ = writer.Write([]byte(`{"data": {"user": {"contributionsCollection": {"contributionCalendar": {"weeks": []}}}}}`))
+	}))
+	defer mockServer.Close()
+
+	_, err = newMockStats(mockServer.URL).CommitsByDay(dateRange)
+	assert.NoError(t, err)
+	assert.Len(t, queries, 2)
+	assert.Contains(t, queries[0], `from: \"2022-07-03T00:00:00+09:00\"`)
+	assert.Contains(t, queries[1], `to: \"2023-07-06T23:59:59+09:00\"`)
+}
+
+func TestRewriter_checkEndDate_timezone(t *testing.T) {
+	// Friday in UTC, Saturday in Tokyo, the current week can be painted on Saturday
+	now := time.Date(2023, 7, 7, 20, 0, 0, 0, time.UTC)
+	r := &Rewriter{endDate: date(2023, 7, 2)}
+	assert.ErrorContains(t, r.checkEndDate(now), "end date is after now")
+
+	r.loc = mustLoadLocation(t, "Asia/Tokyo")
+	assert.NoError(t, r.checkEndDate(now))
+}
+
+func TestRewriter_createCommit_DST(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		day      time.Time
+		wantHour int
+	}{
+		{name: "spring forward", location: "America/New_York", day: date(2023, 3, 12)},
+		{name: "fall back", location: "America/New_York", day: date(2023, 11, 5)},
+		{name: "day before spring forward", location: "Europe/Berlin", day: date(2023, 3, 25)},
+		// the clocks jumped from 00:00 to 01:00, the day starts at 01:00
+		{name: "midnight skipped", location: "America/Santiago", day: date(2022, 9, 11), wantHour: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLoadLocation(t, tt.location)
+			r := &Rewriter{loc: loc}
+
+			when := r.createCommit(tt.day, "Arbitrary commit #1").commitOptions.Author.When
+			assert.Equal(t, tt.day, helper.Day(when, loc))
+			assert.Equal(t, tt.wantHour, when.In(loc).Hour())
+		})
+	}
+}
+
+func Test_newEraseReport_timezone(t *testing.T) {
+	loc := mustLoadLocation(t, "Asia/Tokyo")
+	r := &Rewriter{loc: loc}
+	// the painted day in Tokyo is the previous day in UTC
+	c := r.createCommit(date(2023, 7, 1), "Arbitrary commit #1")
+	assert.Equal(t, date(2023, 6, 30), c.commitOptions.Author.When.UTC().Truncate(24*time.Hour))
+
+	removed := []*object.Commit{{Author: *c.commitOptions.Author}}
+	assert.Equal(t, []time.Time{date(2023, 7, 1)}, newEraseReport(removed, loc).Days)
+}
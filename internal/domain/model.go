@@ -1,17 +1,120 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CalendarHeight is the number of days in a week, which is the number of rows of the contribution calendar
+	CalendarHeight = 7
+	// CalendarWidth is the max number of weeks shown in the contribution calendar
+	CalendarWidth = 53
+	// MaxLevel is the darkest colour level of the contribution calendar, levels are 0 - 4 from light to dark
+	MaxLevel = 4
+)
+
 const (
 	Font75 Font = "75"
 	Font55 Font = "55"
 )
 
+const (
+	SourceLetters Source = "letters"
+	SourceImage   Source = "image"
+	SourceCanvas  Source = "canvas"
+)
+
+const (
+	TimesStart        TimeDistribution = "start"
+	TimesWorkingHours TimeDistribution = "working_hours"
+	TimesRandom       TimeDistribution = "random"
+	TimesHistogram    TimeDistribution = "histogram"
+)
+
+const (
+	ContentEmpty    Content = "empty"
+	ContentJournal  Content = "journal"
+	ContentCanvas   Content = "canvas"
+	ContentSnippets Content = "snippets"
+)
+
+const (
+	CasePreserve Case = "preserve"
+	CaseUpper    Case = "upper"
+	CaseLower    Case = "lower"
+)
+
 // Letter is an m*n 2D array of bools, where m is the height of the letter and n is the width of the letter
 // 1 means the dot is filled
 // 0 means the dot is empty
 type Letter [][]uint
 
+// WithLevel returns a copy of the letter with every filled dot set to the given level
+func (l Letter) WithLevel(level uint) Letter {
+	leveled := make(Letter, len(l))
+	for i, row := range l {
+		leveled[i] = make([]uint, len(row))
+		for j, dot := range row {
+			if dot != 0 {
+				leveled[i][j] = level
+			}
+		}
+	}
+	return leveled
+}
+
+// WithShadow returns a copy of the letter with a drop shadow of the given level cast one dot to the
+// bottom right of every filled dot, the shadow never covers a filled dot
+func (l Letter) WithShadow(level uint) Letter {
+	shadowed := make(Letter, len(l))
+	for i, row := range l {
+		shadowed[i] = append([]uint(nil), row...)
+	}
+
+	for i := 0; i < len(l)-1; i++ {
+		for j := 0; j < len(l[i])-1; j++ {
+			if l[i][j] != 0 && l[i+1][j+1] == 0 {
+				shadowed[i+1][j+1] = level
+			}
+		}
+	}
+	return shadowed
+}
+
+// Columns splits the letter into letters of a single column
+func (l Letter) Columns() []Letter {
+	if len(l) == 0 {
+		return nil
+	}
+
+	columns := make([]Letter, len(l[0]))
+	for j := range columns {
+		columns[j] = make(Letter, len(l))
+		for i, row := range l {
+			columns[j][i] = []uint{row[j]}
+		}
+	}
+	return columns
+}
+
 type Letters []Letter
 
+// Compose concatenates the letters of the same height side by side into a single letter
+func (l Letters) Compose() Letter {
+	if len(l) == 0 {
+		return nil
+	}
+
+	composed := make(Letter, len(l[0]))
+	for _, letter := range l {
+		for i, row := range letter {
+			composed[i] = append(composed[i], row...)
+		}
+	}
+	return composed
+}
+
 func (l Letters) Length() int {
 	length := 0
 	for _, letter := range l {
@@ -20,27 +123,64 @@ func (l Letters) Length() int {
 	return length
 }
 
-// Font includes 7*5 & 5*5
+// Font is the name of a registered font, the built-in fonts are 7*5 & 5*5
 type Font string
 
-func (f Font) Height() int {
-	switch f {
-	case Font75:
-		return 7
-	case Font55:
-		return 5
-	default:
-		return 1<<31 - 1
+// FontFace is a set of glyphs of the same height, every glyph carries its own width
+type FontFace struct {
+	Name   Font
+	Height int
+	Glyphs map[rune]Letter
+
+	// Kerning adjusts the letter spacing between pairs of glyphs, negative values pack them tighter
+	Kerning map[KerningPair]int
+}
+
+// KerningPair is a pair of adjacent letters, left then right
+type KerningPair [2]rune
+
+// GlyphWidth returns the width of the glyph of r, false if the font has no such glyph
+func (f *FontFace) GlyphWidth(r rune) (int, bool) {
+	letter, ok := f.Glyphs[r]
+	if !ok || len(letter) == 0 {
+		return 0, false
+	}
+	return len(letter[0]), true
+}
+
+// Width returns the width of the widest glyph
+func (f *FontFace) Width() int {
+	width := 0
+	for r := range f.Glyphs {
+		if w, _ := f.GlyphWidth(r); w > width {
+			width = w
+		}
 	}
+	return width
 }
 
-func (f Font) Width() int {
-	switch f {
-	case Font75:
-		return 5
-	case Font55:
-		return 5
+// Source is where the picture to paint comes from, the target letters by default
+type Source string
+
+// TimeDistribution is how the commits of a day are spread over the day, all at the start of the day by default
+type TimeDistribution string
+
+// Content is what the commits change in the work tree, nothing by default as the commits are empty
+type Content string
+
+// Case is the policy applied to the target letters before looking up their glyphs
+type Case string
+
+// Apply converts the target according to the case policy, an empty policy preserves the target
+func (c Case) Apply(target string) (string, error) {
+	switch c {
+	case "", CasePreserve:
+		return target, nil
+	case CaseUpper:
+		return strings.ToUpper(target), nil
+	case CaseLower:
+		return strings.ToLower(target), nil
 	default:
-		return 1<<31 - 1
+		return "", fmt.Errorf("unknown case: %s", c)
 	}
 }
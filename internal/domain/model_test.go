@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCase_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Case
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty case should preserve the target", c: "", target: "Hello", want: "Hello"},
+		{name: "preserve case should keep the target as it is", c: CasePreserve, target: "Hello", want: "Hello"},
+		{name: "upper case should convert the target to uppercase", c: CaseUpper, target: "Hello", want: "HELLO"},
+		{name: "lower case should convert the target to lowercase", c: CaseLower, target: "Hello", want: "hello"},
+		{name: "unknown case should return error", c: "title", target: "Hello", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Apply(tt.target)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLetter_WithLevel(t *testing.T) {
+	letter := Letter{{0, 1}, {1, 0}}
+
+	got := letter.WithLevel(MaxLevel)
+
+	assert.Equal(t, Letter{{0, 4}, {4, 0}}, got)
+	assert.Equal(t, Letter{{0, 1}, {1, 0}}, letter, "the letter should not be modified")
+}
+
+func TestLetter_WithShadow(t *testing.T) {
+	letter := Letter{
+		{4, 4, 0},
+		{0, 4, 0},
+		{0, 0, 0},
+	}
+
+	got := letter.WithShadow(1)
+
+	assert.Equal(t, Letter{
+		{4, 4, 0},
+		{0, 4, 1},
+		{0, 0, 1},
+	}, got)
+}
+
+func TestLetters_ComposeAndColumns(t *testing.T) {
+	letters := Letters{
+		{{1, 2}, {3, 4}},
+		{{0}, {0}},
+	}
+
+	composed := letters.Compose()
+	assert.Equal(t, Letter{{1, 2, 0}, {3, 4, 0}}, composed)
+	assert.Equal(t, []Letter{{{1}, {3}}, {{2}, {4}}, {{0}, {0}}}, composed.Columns())
+	assert.Nil(t, Letters{}.Compose())
+}
@@ -1,6 +1,6 @@
 package domain
 
 type Simulator interface {
-	// Simulate should print the simulation of the target letters to the console
-	Simulate(targetLetters string)
+	// Simulate should print the simulation of the target letters, or an error if they don't fit
+	Simulate(targetLetters string) error
 }
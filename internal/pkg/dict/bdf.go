@@ -0,0 +1,173 @@
+package dict
+
+import (
+	"bufio"
+	"contribution-painter/internal/domain"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// bdfBox is a bounding box of BDF, x and y offsets are relative to the origin at the baseline
+type bdfBox struct {
+	width, height, xOff, yOff int
+}
+
+// loadBDF loads a BDF(Glyph Bitmap Distribution Format) font file named after its path,
+// every glyph is placed in a cell of the font bounding box
+func loadBDF(path string) (*domain.FontFace, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open font file failed: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	font, err := parseBDF(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse font file %s failed: %w", path, err)
+	}
+	font.Name = domain.Font(path)
+
+	// space of the built-in fonts is a single blank column used for letter spacing as well
+	delete(font.Glyphs, ' ')
+	return font, nil
+}
+
+func parseBDF(reader io.Reader) (*domain.FontFace, error) {
+	var (
+		font     *domain.FontFace
+		fontBox  bdfBox
+		encoding = -1
+		glyphBox bdfBox
+		bitmap   []string
+		inBitmap bool
+	)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inBitmap {
+			if fields[0] != "ENDCHAR" {
+				bitmap = append(bitmap, fields[0])
+				continue
+			}
+
+			inBitmap = false
+			if encoding < 0 {
+				continue
+			}
+			letter, err := renderBDFGlyph(fontBox, glyphBox, bitmap)
+			if err != nil {
+				return nil, fmt.Errorf("render glyph %q failed: %w", rune(encoding), err)
+			}
+			font.Glyphs[rune(encoding)] = letter
+			continue
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			box, err := parseBDFBox(fields)
+			if err != nil {
+				return nil, err
+			}
+			if box.height > domain.CalendarHeight {
+				return nil, fmt.Errorf("font height %d exceeds %d rows", box.height, domain.CalendarHeight)
+			}
+			fontBox = box
+			font = &domain.FontFace{Height: box.height, Glyphs: make(map[rune]domain.Letter)}
+		case "STARTCHAR":
+			encoding, glyphBox, bitmap = -1, fontBox, nil
+		case "ENCODING":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid ENCODING: %v", fields)
+			}
+			code, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid ENCODING: %w", err)
+			}
+			encoding = code
+		case "BBX":
+			box, err := parseBDFBox(fields)
+			if err != nil {
+				return nil, err
+			}
+			glyphBox = box
+		case "BITMAP":
+			if font == nil {
+				return nil, fmt.Errorf("BITMAP before FONTBOUNDINGBOX")
+			}
+			inBitmap = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read font failed: %w", err)
+	}
+
+	if font == nil || len(font.Glyphs) == 0 {
+		return nil, fmt.Errorf("no glyph found")
+	}
+	return font, nil
+}
+
+// renderBDFGlyph places the glyph bitmap into a cell of the font bounding box
+func renderBDFGlyph(fontBox, glyphBox bdfBox, bitmap []string) (domain.Letter, error) {
+	if len(bitmap) != glyphBox.height {
+		return nil, fmt.Errorf("bitmap has %d rows, BBX height is %d", len(bitmap), glyphBox.height)
+	}
+
+	letter := make(domain.Letter, fontBox.height)
+	for i := range letter {
+		letter[i] = make([]uint, fontBox.width)
+	}
+
+	top := (fontBox.height + fontBox.yOff) - (glyphBox.height + glyphBox.yOff)
+	left := glyphBox.xOff - fontBox.xOff
+	for i, hex := range bitmap {
+		if len(hex)*4 < glyphBox.width {
+			return nil, fmt.Errorf("bitmap row %s is narrower than BBX width %d", hex, glyphBox.width)
+		}
+		bits, err := strconv.ParseUint(hex, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitmap row %s: %w", hex, err)
+		}
+
+		for j := 0; j < glyphBox.width; j++ {
+			if bits>>(len(hex)*4-1-j)&1 == 0 {
+				continue
+			}
+
+			row, col := top+i, left+j
+			if row < 0 || row >= fontBox.height || col < 0 || col >= fontBox.width {
+				return nil, fmt.Errorf("glyph exceeds font bounding box %dx%d", fontBox.width, fontBox.height)
+			}
+			letter[row][col] = 1
+		}
+	}
+
+	return letter, nil
+}
+
+func parseBDFBox(fields []string) (bdfBox, error) {
+	if len(fields) != 5 {
+		return bdfBox{}, fmt.Errorf("invalid %s: %v", fields[0], fields)
+	}
+
+	var values [4]int
+	for i := range values {
+		v, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return bdfBox{}, fmt.Errorf("invalid %s: %w", fields[0], err)
+		}
+		values[i] = v
+	}
+
+	return bdfBox{width: values[0], height: values[1], xOff: values[2], yOff: values[3]}, nil
+}
@@ -0,0 +1,120 @@
+package dict
+
+import (
+	"contribution-painter/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDictionaryFromFile(t *testing.T) {
+	d, err := NewDictionaryFromFile("mocks/font_3x5.bdf")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, d.FontHeight())
+	assert.Equal(t, 3, d.FontWidth())
+
+	got, err := d.GetLetters("Aj.", 1, 0, 0)
+	assert.NoError(t, err)
+
+	space := letterFromRows([]string{".", ".", ".", ".", ".", "."})
+	assert.Equal(t, []domain.Letter{
+		letterFromRows([]string{".#.", "#.#", "###", "#.#", "#.#", "..."}),
+		space,
+		letterFromRows([]string{"...", ".#.", "...", ".#.", ".#.", "#.."}),
+		space,
+		letterFromRows([]string{"...", "...", "...", "...", ".#.", "..."}),
+	}, got)
+
+	_, err = d.GetLetters("B", 1, 0, 0)
+	assert.EqualError(t, err, "unknown letter: B")
+}
+
+func TestNewDictionaryFromFile_NotExist(t *testing.T) {
+	_, err := NewDictionaryFromFile("mocks/not_exist.bdf")
+	assert.Error(t, err)
+}
+
+func Test_parseBDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		bdf     string
+		wantErr string
+	}{
+		{
+			name: "font higher than the calendar should return error",
+			bdf: `STARTFONT 2.1
+FONTBOUNDINGBOX 5 8 0 -1
+ENDFONT`,
+			wantErr: "font height 8 exceeds 7 rows",
+		},
+		{
+			name: "glyph out of the font bounding box should return error",
+			bdf: `STARTFONT 2.1
+FONTBOUNDINGBOX 3 5 0 0
+STARTCHAR A
+ENCODING 65
+BBX 4 1 0 0
+BITMAP
+F0
+ENDCHAR
+ENDFONT`,
+			wantErr: `render glyph 'A' failed: glyph exceeds font bounding box 3x5`,
+		},
+		{
+			name: "bitmap row narrower than the glyph should return error",
+			bdf: `STARTFONT 2.1
+FONTBOUNDINGBOX 9 5 0 0
+STARTCHAR A
+ENCODING 65
+BBX 9 1 0 0
+BITMAP
+FF
+ENDCHAR
+ENDFONT`,
+			wantErr: `render glyph 'A' failed: bitmap row FF is narrower than BBX width 9`,
+		},
+		{
+			name: "font without glyph should return error",
+			bdf: `STARTFONT 2.1
+FONTBOUNDINGBOX 3 5 0 0
+ENDFONT`,
+			wantErr: "no glyph found",
+		},
+		{
+			name: "glyph without encoding should be ignored",
+			bdf: `STARTFONT 2.1
+FONTBOUNDINGBOX 3 5 0 0
+STARTCHAR unknown
+ENCODING -1
+BBX 3 1 0 0
+BITMAP
+E0
+ENDCHAR
+STARTCHAR I
+ENCODING 73
+BBX 1 5 1 0
+BITMAP
+80
+80
+80
+80
+80
+ENDCHAR
+ENDFONT`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := parseBDF(strings.NewReader(tt.bdf))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, font.Glyphs, 1)
+			assert.Equal(t, letterFromRows([]string{".#.", ".#.", ".#.", ".#.", ".#."}), font.Glyphs['I'])
+		})
+	}
+}
@@ -3,55 +3,123 @@ package dict
 import (
 	"contribution-painter/internal/domain"
 	"fmt"
+
+	"github.com/sirupsen/logrus"
 )
 
 var (
-	font75Map = map[rune]domain.Letter{'A': L75A, 'B': L75B, 'C': L75C, 'D': L75D, 'E': L75E, 'F': L75F, 'G': L75G, 'H': L75H, 'I': L75I, 'J': L75J, 'K': L75K, 'L': L75L, 'M': L75M, 'N': L75N, 'O': L75O, 'P': L75P, 'Q': L75Q, 'R': L75R, 'S': L75S, 'T': L75T, 'U': L75U, 'V': L75V, 'W': L75W, 'X': L75X, 'Y': L75Y, 'Z': L75Z, ' ': L7Space}
-	font55Map = map[rune]domain.Letter{'A': L55A, 'B': L55B, 'C': L55C, 'D': L55D, 'E': L55E, 'F': L55F, 'G': L55G, 'H': L55H, 'I': L55I, 'J': L55J, 'K': L55K, 'L': L55L, 'M': L55M, 'N': L55N, 'O': L55O, 'P': L55P, 'Q': L55Q, 'R': L55R, 'S': L55S, 'T': L55T, 'U': L55U, 'V': L55V, 'W': L55W, 'X': L55X, 'Y': L55Y, 'Z': L55Z, ' ': L5Space}
+	font75Map = map[rune]domain.Letter{
+		' ': L7Space,
+		'A': L75A, 'B': L75B, 'C': L75C, 'D': L75D, 'E': L75E, 'F': L75F, 'G': L75G, 'H': L75H, 'I': L75I, 'J': L75J, 'K': L75K, 'L': L75L, 'M': L75M, 'N': L75N, 'O': L75O, 'P': L75P, 'Q': L75Q, 'R': L75R, 'S': L75S, 'T': L75T, 'U': L75U, 'V': L75V, 'W': L75W, 'X': L75X, 'Y': L75Y, 'Z': L75Z,
+		'a': L75a, 'b': L75b, 'c': L75c, 'd': L75d, 'e': L75e, 'f': L75f, 'g': L75g, 'h': L75h, 'i': L75i, 'j': L75j, 'k': L75k, 'l': L75l, 'm': L75m, 'n': L75n, 'o': L75o, 'p': L75p, 'q': L75q, 'r': L75r, 's': L75s, 't': L75t, 'u': L75u, 'v': L75v, 'w': L75w, 'x': L75x, 'y': L75y, 'z': L75z,
+		'0': L75Digit0, '1': L75Digit1, '2': L75Digit2, '3': L75Digit3, '4': L75Digit4, '5': L75Digit5, '6': L75Digit6, '7': L75Digit7, '8': L75Digit8, '9': L75Digit9,
+		'!': L75Exclamation, '?': L75Question, '.': L75Period, ',': L75Comma, ':': L75Colon, ';': L75Semicolon, '-': L75Hyphen, '+': L75Plus, '_': L75Underscore, '/': L75Slash, '\\': L75Backslash, '#': L75Hash, '@': L75At, '&': L75Ampersand, '*': L75Asterisk, '(': L75LeftParen, ')': L75RightParen, '<': L75Less, '>': L75Greater, '=': L75Equal, '\'': L75Apostrophe, '"': L75Quote,
+	}
+	font55Map = map[rune]domain.Letter{
+		' ': L5Space,
+		'A': L55A, 'B': L55B, 'C': L55C, 'D': L55D, 'E': L55E, 'F': L55F, 'G': L55G, 'H': L55H, 'I': L55I, 'J': L55J, 'K': L55K, 'L': L55L, 'M': L55M, 'N': L55N, 'O': L55O, 'P': L55P, 'Q': L55Q, 'R': L55R, 'S': L55S, 'T': L55T, 'U': L55U, 'V': L55V, 'W': L55W, 'X': L55X, 'Y': L55Y, 'Z': L55Z,
+		'0': L55Digit0, '1': L55Digit1, '2': L55Digit2, '3': L55Digit3, '4': L55Digit4, '5': L55Digit5, '6': L55Digit6, '7': L55Digit7, '8': L55Digit8, '9': L55Digit9,
+		'!': L55Exclamation, '?': L55Question, '.': L55Period, ',': L55Comma, ':': L55Colon, ';': L55Semicolon, '-': L55Hyphen, '+': L55Plus, '_': L55Underscore, '/': L55Slash, '\\': L55Backslash, '#': L55Hash, '@': L55At, '&': L55Ampersand, '*': L55Asterisk, '(': L55LeftParen, ')': L55RightParen, '<': L55Less, '>': L55Greater, '=': L55Equal, '\'': L55Apostrophe, '"': L55Quote,
+	}
 )
 
+func init() {
+	MustRegister(&domain.FontFace{Name: domain.Font75, Height: 7, Glyphs: font75Map})
+	MustRegister(&domain.FontFace{Name: domain.Font55, Height: 5, Glyphs: font55Map})
+}
+
 type Dictionary struct {
 	font domain.Font
+
+	proportional bool
+	kerning      map[domain.KerningPair]int
 }
 
-func NewDictionary(font domain.Font) *Dictionary {
-	return &Dictionary{font: font}
+// Option configures how a Dictionary lays out the letters
+type Option func(d *Dictionary)
+
+// WithProportional trims the blank columns on both sides of every glyph, so each glyph takes its own width
+func WithProportional() Option {
+	return func(d *Dictionary) {
+		d.proportional = true
+	}
+}
+
+// WithKerning adjusts the letter spacing between pairs of letters, it overrides the kerning of the font
+func WithKerning(kerning map[domain.KerningPair]int) Option {
+	return func(d *Dictionary) {
+		d.kerning = kerning
+	}
+}
+
+// NewDictionary creates a Dictionary of a registered font
+func NewDictionary(font domain.Font, opts ...Option) *Dictionary {
+	d := &Dictionary{font: font}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// NewDictionaryFromFile registers the BDF font file and creates a Dictionary of it
+func NewDictionaryFromFile(path string, opts ...Option) (*Dictionary, error) {
+	face, err := loadBDF(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Register(face); err != nil {
+		return nil, fmt.Errorf("register font failed: %w", err)
+	}
+	return NewDictionary(face.Name, opts...), nil
 }
 
 func (d *Dictionary) GetLetters(target string, letterSpacing, leadingSpace, trailingSpace int) ([]domain.Letter, error) {
 	var letters []domain.Letter
 
-	var fontMap map[rune]domain.Letter
-	switch d.font {
-	case domain.Font75:
-		fontMap = font75Map
-	case domain.Font55:
-		fontMap = font55Map
-	default:
+	face, ok := Lookup(d.font)
+	if !ok {
 		return nil, fmt.Errorf("unknown font: %s", d.font)
 	}
+	fontMap := face.Glyphs
 
 	// add leading space
 	for i := 0; i < leadingSpace; i++ {
 		letters = append(letters, fontMap[' '])
 	}
 
-	for _, r := range []rune(target) {
+	runes := []rune(target)
+	// overlap is the columns the letter is pulled back into the previous letter by a negative spacing
+	overlap := 0
+	for i, r := range runes {
 		letter, ok := fontMap[r]
 		if !ok {
 			return nil, fmt.Errorf("unknown letter: %s", string(r))
 		}
-		letters = append(letters, letter)
+		if d.proportional {
+			letter = trimLetter(letter)
+		}
+		if overlap > 0 {
+			previous := letters[len(letters)-1]
+			if maxOverlap := min(len(previous[0]), len(letter[0])); overlap > maxOverlap {
+				logrus.Warnf("kerning of %q overlaps %d columns, more than the glyphs, clamped to %d",
+					string(runes[i-1:i+1]), overlap, maxOverlap)
+				overlap = maxOverlap
+			}
+			letters[len(letters)-1] = overlapLetters(previous, letter, overlap)
+		} else {
+			letters = append(letters, letter)
+		}
 
-		// add space
-		for i := 0; i < letterSpacing; i++ {
+		// add space between letters, no space after the last letter
+		if i == len(runes)-1 {
+			break
+		}
+		spacing := letterSpacing + d.kern(face, r, runes[i+1])
+		for j := 0; j < spacing; j++ {
 			letters = append(letters, fontMap[' '])
 		}
-	}
-
-	// remove last space
-	if len(letters) > 0 {
-		letters = letters[:len(letters)-letterSpacing]
+		overlap = -spacing
 	}
 
 	// add trailing space
@@ -62,10 +130,83 @@ func (d *Dictionary) GetLetters(target string, letterSpacing, leadingSpace, trai
 	return letters, nil
 }
 
+// FontHeight returns the height of the font, 0 if the font is not registered
 func (d *Dictionary) FontHeight() int {
-	return d.font.Height()
+	face, ok := Lookup(d.font)
+	if !ok {
+		return 0
+	}
+	return face.Height
 }
 
+// FontWidth returns the width of the widest glyph, 0 if the font is not registered
 func (d *Dictionary) FontWidth() int {
-	return d.font.Width()
+	face, ok := Lookup(d.font)
+	if !ok {
+		return 0
+	}
+	return face.Width()
+}
+
+// kern returns the spacing adjustment between left and right, the kerning of the dictionary takes precedence
+func (d *Dictionary) kern(face *domain.FontFace, left, right rune) int {
+	pair := domain.KerningPair{left, right}
+	if adjust, ok := d.kerning[pair]; ok {
+		return adjust
+	}
+	return face.Kerning[pair]
+}
+
+// overlapLetters joins the letters with the last columns of the left letter overlapping the first columns
+// of the right letter, the overlapped dots take the darker level
+func overlapLetters(left, right domain.Letter, overlap int) domain.Letter {
+	offset := len(left[0]) - overlap
+	joined := make(domain.Letter, len(left))
+	for i := range joined {
+		row := make([]uint, offset+len(right[i]))
+		copy(row, left[i])
+		for j, level := range right[i] {
+			if level > row[offset+j] {
+				row[offset+j] = level
+			}
+		}
+		joined[i] = row
+	}
+	return joined
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// trimLetter removes the blank columns on both sides of the letter, a blank letter is kept as it is
+func trimLetter(letter domain.Letter) domain.Letter {
+	blank := func(col int) bool {
+		for _, row := range letter {
+			if row[col] != 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	left, right := 0, len(letter[0])
+	for left < right && blank(left) {
+		left++
+	}
+	for right > left && blank(right-1) {
+		right--
+	}
+	if left == right {
+		return letter
+	}
+
+	trimmed := make(domain.Letter, len(letter))
+	for i, row := range letter {
+		trimmed[i] = row[left:right]
+	}
+	return trimmed
 }
@@ -61,3 +61,935 @@ func TestDictionary_GetLetter(t *testing.T) {
 		})
 	}
 }
+
+func TestDictionary_GetLetters_Golden(t *testing.T) {
+	tests := []struct {
+		name   string
+		font   domain.Font
+		golden map[rune][]string
+	}{
+		{
+			name:   "digits and punctuation of Font75",
+			font:   domain.Font75,
+			golden: golden75,
+		},
+		{
+			name:   "lowercase letters of Font75",
+			font:   domain.Font75,
+			golden: golden75Lower,
+		},
+		{
+			name:   "digits and punctuation of Font55",
+			font:   domain.Font55,
+			golden: golden55,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDictionary(tt.font)
+			for r, rows := range tt.golden {
+				got, err := d.GetLetters(string(r), 0, 0, 0)
+				assert.NoErrorf(t, err, "glyph %q", r)
+				assert.Equalf(t, []domain.Letter{letterFromRows(rows)}, got, "glyph %q", r)
+			}
+		})
+	}
+}
+
+func TestDictionary_GetLetters_Text(t *testing.T) {
+	d := NewDictionary(domain.Font75)
+
+	got, err := d.GetLetters("C++ <3", 1, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Letter{
+		L75C, L7Space, L75Plus, L7Space, L75Plus, L7Space, L7Space, L7Space, L75Less, L7Space, L75Digit3,
+	}, got)
+
+	_, err = d.GetLetters("2024~", 1, 0, 0)
+	assert.EqualError(t, err, "unknown letter: ~")
+}
+
+func TestDictionary_GetLetters_MixedCase(t *testing.T) {
+	d := NewDictionary(domain.Font75)
+
+	// the lowercase letters sit on the baseline of the capitals
+	got, err := d.GetLetters("Hello", 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Letter{
+		letterFromRows([]string{"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"}),
+		letterFromRows([]string{".....", ".....", ".....", ".###.", "#####", "#....", ".###."}),
+		letterFromRows([]string{".....", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."}),
+		letterFromRows([]string{".....", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."}),
+		letterFromRows([]string{".....", ".....", ".....", ".###.", "#...#", "#...#", ".###."}),
+	}, got)
+}
+
+func TestDictionary_GetLetters_ProportionalAndKerning(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []domain.Letter
+	}{
+		{
+			name: "monospaced glyphs should keep the width of the font",
+			want: []domain.Letter{L75H, L7Space, L7Space, L75I, L7Space, L7Space, L75L},
+		},
+		{
+			name: "proportional glyphs should be trimmed",
+			opts: []Option{WithProportional()},
+			want: []domain.Letter{
+				L75H, L7Space, L7Space,
+				letterFromRows([]string{"###", ".#.", ".#.", ".#.", ".#.", ".#.", "###"}),
+				L7Space, L7Space, L75L,
+			},
+		},
+		{
+			name: "kerning should adjust the spacing of the pair, beyond the spacing the letters overlap",
+			opts: []Option{WithKerning(map[domain.KerningPair]int{{'H', 'I'}: -1, {'I', 'L'}: -5})},
+			want: []domain.Letter{L75H, L7Space, overlapLetters(L75I, L75L, 3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewDictionary(domain.Font75, tt.opts...).GetLetters("HIL", 2, 0, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDictionary_GetLetters_FontKerning(t *testing.T) {
+	face := &domain.FontFace{
+		Name:   "test-kerning",
+		Height: 1,
+		Glyphs: map[rune]domain.Letter{
+			'A': letterFromRows([]string{"#"}),
+			'B': letterFromRows([]string{"#"}),
+		},
+		Kerning: map[domain.KerningPair]int{{'A', 'B'}: -1},
+	}
+	assert.NoError(t, Register(face))
+	registered, _ := Lookup(face.Name)
+	space := registered.Glyphs[' ']
+
+	got, err := NewDictionary(face.Name).GetLetters("ABA", 2, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Letter{face.Glyphs['A'], space, face.Glyphs['B'], space, space, face.Glyphs['A']}, got)
+
+	got, err = NewDictionary(face.Name, WithKerning(map[domain.KerningPair]int{{'A', 'B'}: 0})).GetLetters("AB", 2, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Letter{face.Glyphs['A'], space, space, face.Glyphs['B']}, got)
+}
+
+func TestDictionary_GetLetters_NegativeSpacing(t *testing.T) {
+	face := &domain.FontFace{
+		Name:   "test-overlap",
+		Height: 2,
+		Glyphs: map[rune]domain.Letter{
+			'A': letterFromRows([]string{"##", "#."}),
+			'B': letterFromRows([]string{"##", ".#"}),
+		},
+	}
+	assert.NoError(t, Register(face))
+
+	tests := []struct {
+		name          string
+		letterSpacing int
+		adjust        int
+		want          []domain.Letter
+	}{
+		{
+			name:   "kerning without letter spacing should pull the letters together",
+			adjust: -1,
+			want:   []domain.Letter{letterFromRows([]string{"###", "#.#"})},
+		},
+		{
+			name:          "kerning beyond the letter spacing should overlap the letters",
+			letterSpacing: 1,
+			adjust:        -2,
+			want:          []domain.Letter{letterFromRows([]string{"###", "#.#"})},
+		},
+		{
+			name:   "overlap wider than the glyphs should be clamped",
+			adjust: -5,
+			want:   []domain.Letter{letterFromRows([]string{"##", "##"})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDictionary(face.Name, WithKerning(map[domain.KerningPair]int{{'A', 'B'}: tt.adjust}))
+			got, err := d.GetLetters("AB", tt.letterSpacing, 0, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// letterFromRows converts rows of '#' (filled) and '.' (empty) into a domain.Letter
+func letterFromRows(rows []string) domain.Letter {
+	letter := make(domain.Letter, len(rows))
+	for i, row := range rows {
+		letter[i] = make([]uint, len(row))
+		for j, c := range row {
+			if c == '#' {
+				letter[i][j] = 1
+			}
+		}
+	}
+	return letter
+}
+
+var golden75 = map[rune][]string{
+	'0': {
+		".###.",
+		"#...#",
+		"#..##",
+		"#.#.#",
+		"##..#",
+		"#...#",
+		".###.",
+	},
+	'1': {
+		"..#..",
+		".##..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'2': {
+		".###.",
+		"#...#",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		"#####",
+	},
+	'3': {
+		"#####",
+		"...#.",
+		"..#..",
+		"...#.",
+		"....#",
+		"#...#",
+		".###.",
+	},
+	'4': {
+		"...#.",
+		"..##.",
+		".#.#.",
+		"#..#.",
+		"#####",
+		"...#.",
+		"...#.",
+	},
+	'5': {
+		"#####",
+		"#....",
+		"####.",
+		"....#",
+		"....#",
+		"#...#",
+		".###.",
+	},
+	'6': {
+		"..##.",
+		".#...",
+		"#....",
+		"####.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'7': {
+		"#####",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		".#...",
+		".#...",
+	},
+	'8': {
+		".###.",
+		"#...#",
+		"#...#",
+		".###.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'9': {
+		".###.",
+		"#...#",
+		"#...#",
+		".####",
+		"....#",
+		"...#.",
+		".##..",
+	},
+	'!': {
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		".....",
+		"..#..",
+	},
+	'?': {
+		".###.",
+		"#...#",
+		"....#",
+		"...#.",
+		"..#..",
+		".....",
+		"..#..",
+	},
+	'.': {
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		"..#..",
+	},
+	',': {
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		"..#..",
+		".#...",
+	},
+	':': {
+		".....",
+		"..#..",
+		".....",
+		".....",
+		".....",
+		"..#..",
+		".....",
+	},
+	';': {
+		".....",
+		"..#..",
+		".....",
+		".....",
+		"..#..",
+		"..#..",
+		".#...",
+	},
+	'-': {
+		".....",
+		".....",
+		".....",
+		"#####",
+		".....",
+		".....",
+		".....",
+	},
+	'+': {
+		".....",
+		"..#..",
+		"..#..",
+		"#####",
+		"..#..",
+		"..#..",
+		".....",
+	},
+	'_': {
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		"#####",
+	},
+	'/': {
+		"....#",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		"#....",
+		"#....",
+	},
+	'\\': {
+		"#....",
+		"#....",
+		".#...",
+		"..#..",
+		"...#.",
+		"....#",
+		"....#",
+	},
+	'#': {
+		".#.#.",
+		".#.#.",
+		"#####",
+		".#.#.",
+		"#####",
+		".#.#.",
+		".#.#.",
+	},
+	'@': {
+		".###.",
+		"#...#",
+		"#.###",
+		"#.#.#",
+		"#.###",
+		"#....",
+		".####",
+	},
+	'&': {
+		".##..",
+		"#..#.",
+		"#.#..",
+		".#...",
+		"#.#.#",
+		"#..#.",
+		".##.#",
+	},
+	'*': {
+		".....",
+		"#.#.#",
+		".###.",
+		"#####",
+		".###.",
+		"#.#.#",
+		".....",
+	},
+	'(': {
+		"...#.",
+		"..#..",
+		".#...",
+		".#...",
+		".#...",
+		"..#..",
+		"...#.",
+	},
+	')': {
+		".#...",
+		"..#..",
+		"...#.",
+		"...#.",
+		"...#.",
+		"..#..",
+		".#...",
+	},
+	'<': {
+		"...#.",
+		"..#..",
+		".#...",
+		"#....",
+		".#...",
+		"..#..",
+		"...#.",
+	},
+	'>': {
+		".#...",
+		"..#..",
+		"...#.",
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+	},
+	'=': {
+		".....",
+		".....",
+		"#####",
+		".....",
+		"#####",
+		".....",
+		".....",
+	},
+	'\'': {
+		"..#..",
+		"..#..",
+		".#...",
+		".....",
+		".....",
+		".....",
+		".....",
+	},
+	'"': {
+		".#.#.",
+		".#.#.",
+		".#.#.",
+		".....",
+		".....",
+		".....",
+		".....",
+	},
+}
+
+var golden75Lower = map[rune][]string{
+	'a': {
+		".....",
+		".....",
+		".....",
+		".####",
+		"#...#",
+		"#..##",
+		".##.#",
+	},
+	'b': {
+		".....",
+		"#....",
+		"#....",
+		"####.",
+		"#...#",
+		"#...#",
+		"####.",
+	},
+	'c': {
+		".....",
+		".....",
+		".....",
+		".####",
+		"#....",
+		"#....",
+		".####",
+	},
+	'd': {
+		".....",
+		"....#",
+		"....#",
+		".####",
+		"#...#",
+		"#...#",
+		".####",
+	},
+	'e': {
+		".....",
+		".....",
+		".....",
+		".###.",
+		"#####",
+		"#....",
+		".###.",
+	},
+	'f': {
+		".....",
+		"..##.",
+		".#...",
+		"####.",
+		".#...",
+		".#...",
+		".#...",
+	},
+	'g': {
+		".....",
+		".....",
+		".....",
+		".####",
+		"#...#",
+		".####",
+		"####.",
+	},
+	'h': {
+		".....",
+		"#....",
+		"#....",
+		"####.",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'i': {
+		".....",
+		"..#..",
+		".....",
+		".##..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'j': {
+		".....",
+		"...#.",
+		".....",
+		"..##.",
+		"...#.",
+		"...#.",
+		".##..",
+	},
+	'k': {
+		".....",
+		"#....",
+		"#....",
+		"#..#.",
+		"###..",
+		"#.#..",
+		"#..#.",
+	},
+	'l': {
+		".....",
+		".##..",
+		"..#..",
+		"..#..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'm': {
+		".....",
+		".....",
+		".....",
+		"##.#.",
+		"#.#.#",
+		"#.#.#",
+		"#.#.#",
+	},
+	'n': {
+		".....",
+		".....",
+		".....",
+		"####.",
+		"#...#",
+		"#...#",
+		"#...#",
+	},
+	'o': {
+		".....",
+		".....",
+		".....",
+		".###.",
+		"#...#",
+		"#...#",
+		".###.",
+	},
+	'p': {
+		".....",
+		".....",
+		".....",
+		"####.",
+		"#...#",
+		"####.",
+		"#....",
+	},
+	'q': {
+		".....",
+		".....",
+		".....",
+		".####",
+		"#...#",
+		".####",
+		"....#",
+	},
+	'r': {
+		".....",
+		".....",
+		".....",
+		"#.##.",
+		"##..#",
+		"#....",
+		"#....",
+	},
+	's': {
+		".....",
+		".....",
+		".....",
+		".####",
+		"##...",
+		"...##",
+		"####.",
+	},
+	't': {
+		".....",
+		".#...",
+		".#...",
+		"####.",
+		".#...",
+		".#...",
+		"..##.",
+	},
+	'u': {
+		".....",
+		".....",
+		".....",
+		"#...#",
+		"#...#",
+		"#...#",
+		".####",
+	},
+	'v': {
+		".....",
+		".....",
+		".....",
+		"#...#",
+		"#...#",
+		".#.#.",
+		"..#..",
+	},
+	'w': {
+		".....",
+		".....",
+		".....",
+		"#...#",
+		"#.#.#",
+		"#.#.#",
+		".#.#.",
+	},
+	'x': {
+		".....",
+		".....",
+		".....",
+		"#...#",
+		".#.#.",
+		".#.#.",
+		"#...#",
+	},
+	'y': {
+		".....",
+		".....",
+		".....",
+		"#...#",
+		"#...#",
+		".####",
+		"####.",
+	},
+	'z': {
+		".....",
+		".....",
+		".....",
+		"#####",
+		"...#.",
+		".#...",
+		"#####",
+	},
+}
+
+var golden55 = map[rune][]string{
+	'0': {
+		".###.",
+		"#..##",
+		"#.#.#",
+		"##..#",
+		".###.",
+	},
+	'1': {
+		"..#..",
+		".##..",
+		"..#..",
+		"..#..",
+		".###.",
+	},
+	'2': {
+		"####.",
+		"....#",
+		".###.",
+		"#....",
+		"#####",
+	},
+	'3': {
+		"####.",
+		"....#",
+		".###.",
+		"....#",
+		"####.",
+	},
+	'4': {
+		"#..#.",
+		"#..#.",
+		"#####",
+		"...#.",
+		"...#.",
+	},
+	'5': {
+		"#####",
+		"#....",
+		"####.",
+		"....#",
+		"####.",
+	},
+	'6': {
+		".###.",
+		"#....",
+		"####.",
+		"#...#",
+		".###.",
+	},
+	'7': {
+		"#####",
+		"...#.",
+		"..#..",
+		".#...",
+		".#...",
+	},
+	'8': {
+		".###.",
+		"#...#",
+		".###.",
+		"#...#",
+		".###.",
+	},
+	'9': {
+		".###.",
+		"#...#",
+		".####",
+		"....#",
+		".###.",
+	},
+	'!': {
+		"..#..",
+		"..#..",
+		"..#..",
+		".....",
+		"..#..",
+	},
+	'?': {
+		".###.",
+		"#...#",
+		"..##.",
+		".....",
+		"..#..",
+	},
+	'.': {
+		".....",
+		".....",
+		".....",
+		".....",
+		"..#..",
+	},
+	',': {
+		".....",
+		".....",
+		".....",
+		"..#..",
+		".#...",
+	},
+	':': {
+		".....",
+		"..#..",
+		".....",
+		"..#..",
+		".....",
+	},
+	';': {
+		".....",
+		"..#..",
+		".....",
+		"..#..",
+		".#...",
+	},
+	'-': {
+		".....",
+		".....",
+		"#####",
+		".....",
+		".....",
+	},
+	'+': {
+		"..#..",
+		"..#..",
+		"#####",
+		"..#..",
+		"..#..",
+	},
+	'_': {
+		".....",
+		".....",
+		".....",
+		".....",
+		"#####",
+	},
+	'/': {
+		"....#",
+		"...#.",
+		"..#..",
+		".#...",
+		"#....",
+	},
+	'\\': {
+		"#....",
+		".#...",
+		"..#..",
+		"...#.",
+		"....#",
+	},
+	'#': {
+		".#.#.",
+		"#####",
+		".#.#.",
+		"#####",
+		".#.#.",
+	},
+	'@': {
+		".###.",
+		"#.#.#",
+		"#.###",
+		"#....",
+		".###.",
+	},
+	'&': {
+		".##..",
+		"#..#.",
+		".##.#",
+		"#..#.",
+		".##.#",
+	},
+	'*': {
+		"#.#.#",
+		".###.",
+		"#####",
+		".###.",
+		"#.#.#",
+	},
+	'(': {
+		"...#.",
+		"..#..",
+		"..#..",
+		"..#..",
+		"...#.",
+	},
+	')': {
+		".#...",
+		"..#..",
+		"..#..",
+		"..#..",
+		".#...",
+	},
+	'<': {
+		"...#.",
+		"..#..",
+		".#...",
+		"..#..",
+		"...#.",
+	},
+	'>': {
+		".#...",
+		"..#..",
+		"...#.",
+		"..#..",
+		".#...",
+	},
+	'=': {
+		".....",
+		"#####",
+		".....",
+		"#####",
+		".....",
+	},
+	'\'': {
+		"..#..",
+		"..#..",
+		".....",
+		".....",
+		".....",
+	},
+	'"': {
+		".#.#.",
+		".#.#.",
+		".....",
+		".....",
+		".....",
+	},
+}
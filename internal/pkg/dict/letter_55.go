@@ -194,3 +194,231 @@ var (
 		{1, 1, 1, 1, 1},
 	}
 )
+
+// digits and punctuation of an 5*5 2D array of uint
+var (
+	L55Digit0 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 1, 1},
+		{1, 0, 1, 0, 1},
+		{1, 1, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L55Digit1 = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L55Digit2 = domain.Letter{
+		{1, 1, 1, 1, 0},
+		{0, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+	L55Digit3 = domain.Letter{
+		{1, 1, 1, 1, 0},
+		{0, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+		{0, 0, 0, 0, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L55Digit4 = domain.Letter{
+		{1, 0, 0, 1, 0},
+		{1, 0, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L55Digit5 = domain.Letter{
+		{1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{0, 0, 0, 0, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L55Digit6 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L55Digit7 = domain.Letter{
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L55Digit8 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L55Digit9 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{0, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L55Exclamation = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L55Question = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 0, 1, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L55Period = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L55Comma = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L55Colon = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L55Semicolon = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L55Hyphen = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L55Plus = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L55Underscore = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+	L55Slash = domain.Letter{
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+	}
+	L55Backslash = domain.Letter{
+		{1, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1},
+	}
+	L55Hash = domain.Letter{
+		{0, 1, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 0, 1, 0},
+	}
+	L55At = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 1, 0, 1},
+		{1, 0, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L55Ampersand = domain.Letter{
+		{0, 1, 1, 0, 0},
+		{1, 0, 0, 1, 0},
+		{0, 1, 1, 0, 1},
+		{1, 0, 0, 1, 0},
+		{0, 1, 1, 0, 1},
+	}
+	L55Asterisk = domain.Letter{
+		{1, 0, 1, 0, 1},
+		{0, 1, 1, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 1, 1, 0},
+		{1, 0, 1, 0, 1},
+	}
+	L55LeftParen = domain.Letter{
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L55RightParen = domain.Letter{
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L55Less = domain.Letter{
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L55Greater = domain.Letter{
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L55Equal = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+	}
+	L55Apostrophe = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L55Quote = domain.Letter{
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+)
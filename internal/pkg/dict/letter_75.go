@@ -248,3 +248,534 @@ var (
 		{1, 1, 1, 1, 1},
 	}
 )
+
+// digits and punctuation of an 7*5 2D array of uint
+var (
+	L75Digit0 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 1, 1},
+		{1, 0, 1, 0, 1},
+		{1, 1, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit1 = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit2 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+	L75Digit3 = domain.Letter{
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit4 = domain.Letter{
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 1, 0},
+		{0, 1, 0, 1, 0},
+		{1, 0, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L75Digit5 = domain.Letter{
+		{1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit6 = domain.Letter{
+		{0, 0, 1, 1, 0},
+		{0, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit7 = domain.Letter{
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75Digit8 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75Digit9 = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 1, 1, 0, 0},
+	}
+	L75Exclamation = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L75Question = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L75Period = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L75Comma = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75Colon = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L75Semicolon = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75Hyphen = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L75Plus = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L75Underscore = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+	L75Slash = domain.Letter{
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+	}
+	L75Backslash = domain.Letter{
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+	}
+	L75Hash = domain.Letter{
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 0, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+	}
+	L75At = domain.Letter{
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 1, 1, 1},
+		{1, 0, 1, 0, 1},
+		{1, 0, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+	}
+	L75Ampersand = domain.Letter{
+		{0, 1, 1, 0, 0},
+		{1, 0, 0, 1, 0},
+		{1, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 0, 1, 0, 1},
+		{1, 0, 0, 1, 0},
+		{0, 1, 1, 0, 1},
+	}
+	L75Asterisk = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{1, 0, 1, 0, 1},
+		{0, 1, 1, 1, 0},
+		{1, 1, 1, 1, 1},
+		{0, 1, 1, 1, 0},
+		{1, 0, 1, 0, 1},
+		{0, 0, 0, 0, 0},
+	}
+	L75LeftParen = domain.Letter{
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L75RightParen = domain.Letter{
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75Less = domain.Letter{
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	L75Greater = domain.Letter{
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75Equal = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L75Apostrophe = domain.Letter{
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	L75Quote = domain.Letter{
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+)
+
+// lowercase letters of an 7*5 2D array of uint, they sit on the bottom row like the capitals,
+// so descenders are shallow: their body ends a row above and only the tail reaches the bottom row
+var (
+	L75a = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 1, 1},
+		{0, 1, 1, 0, 1},
+	}
+	L75b = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L75c = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+	}
+	L75d = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 1},
+		{0, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+	}
+	L75e = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+		{1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L75f = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 1, 0},
+		{0, 1, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+	}
+	L75g = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L75h = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+	}
+	L75i = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L75j = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 1, 0},
+		{0, 0, 0, 1, 0},
+		{0, 0, 0, 1, 0},
+		{0, 1, 1, 0, 0},
+	}
+	L75k = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 1, 0},
+		{1, 1, 1, 0, 0},
+		{1, 0, 1, 0, 0},
+		{1, 0, 0, 1, 0},
+	}
+	L75l = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 1, 1, 0},
+	}
+	L75m = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 0, 1, 0},
+		{1, 0, 1, 0, 1},
+		{1, 0, 1, 0, 1},
+		{1, 0, 1, 0, 1},
+	}
+	L75n = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+	}
+	L75o = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 0},
+	}
+	L75p = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 1},
+		{1, 1, 1, 1, 0},
+		{1, 0, 0, 0, 0},
+	}
+	L75q = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{0, 0, 0, 0, 1},
+	}
+	L75r = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 1, 1, 0},
+		{1, 1, 0, 0, 1},
+		{1, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0},
+	}
+	L75s = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 1},
+		{1, 1, 0, 0, 0},
+		{0, 0, 0, 1, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L75t = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{1, 1, 1, 1, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 1, 0},
+	}
+	L75u = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+	}
+	L75v = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 0, 1, 0},
+		{0, 0, 1, 0, 0},
+	}
+	L75w = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 1, 0, 1},
+		{1, 0, 1, 0, 1},
+		{0, 1, 0, 1, 0},
+	}
+	L75x = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1},
+		{0, 1, 0, 1, 0},
+		{0, 1, 0, 1, 0},
+		{1, 0, 0, 0, 1},
+	}
+	L75y = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 1},
+		{0, 1, 1, 1, 1},
+		{1, 1, 1, 1, 0},
+	}
+	L75z = domain.Letter{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{0, 1, 0, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+)
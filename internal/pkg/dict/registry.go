@@ -0,0 +1,94 @@
+package dict
+
+import (
+	"contribution-painter/internal/domain"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	fonts map[domain.Font]*domain.FontFace
+}{fonts: make(map[domain.Font]*domain.FontFace)}
+
+// Register adds a copy of the font to the registry, a font with the same name is replaced.
+// A single blank column is used as the space glyph if the font has none.
+func Register(face *domain.FontFace) error {
+	if face.Name == "" {
+		return fmt.Errorf("font name is empty")
+	}
+	if face.Height <= 0 || face.Height > domain.CalendarHeight {
+		return fmt.Errorf("font %s: height %d is not in [1, %d]", face.Name, face.Height, domain.CalendarHeight)
+	}
+	if len(face.Glyphs) == 0 {
+		return fmt.Errorf("font %s has no glyph", face.Name)
+	}
+
+	for r, letter := range face.Glyphs {
+		if len(letter) != face.Height {
+			return fmt.Errorf("font %s: glyph %q has %d rows, want %d", face.Name, r, len(letter), face.Height)
+		}
+		for _, row := range letter {
+			if len(row) == 0 || len(row) != len(letter[0]) {
+				return fmt.Errorf("font %s: glyph %q has rows of different widths", face.Name, r)
+			}
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	// the glyphs and the kerning of the caller are left as they are, and later changes to them are not seen
+	registered := *face
+	registered.Glyphs = make(map[rune]domain.Letter, len(face.Glyphs)+1)
+	for r, letter := range face.Glyphs {
+		registered.Glyphs[r] = letter
+	}
+	if face.Kerning != nil {
+		registered.Kerning = make(map[domain.KerningPair]int, len(face.Kerning))
+		for pair, adjust := range face.Kerning {
+			registered.Kerning[pair] = adjust
+		}
+	}
+	if _, ok := registered.Glyphs[' ']; !ok {
+		space := make(domain.Letter, face.Height)
+		for i := range space {
+			space[i] = []uint{0}
+		}
+		registered.Glyphs[' '] = space
+	}
+
+	registry.fonts[face.Name] = &registered
+	return nil
+}
+
+// MustRegister is like Register but panics if the font is invalid
+func MustRegister(face *domain.FontFace) {
+	if err := Register(face); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered font with the given name
+func Lookup(name domain.Font) (*domain.FontFace, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	face, ok := registry.fonts[name]
+	return face, ok
+}
+
+// Fonts returns all registered fonts ordered by name
+func Fonts() []*domain.FontFace {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	fonts := make([]*domain.FontFace, 0, len(registry.fonts))
+	for _, face := range registry.fonts {
+		fonts = append(fonts, face)
+	}
+	sort.Slice(fonts, func(i, j int) bool {
+		return fonts[i].Name < fonts[j].Name
+	})
+	return fonts
+}
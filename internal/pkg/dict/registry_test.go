@@ -0,0 +1,121 @@
+package dict
+
+import (
+	"contribution-painter/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name    string
+		face    *domain.FontFace
+		wantErr string
+	}{
+		{
+			name: "generated font should be registered with a space glyph",
+			face: &domain.FontFace{
+				Name:   "test-2x3",
+				Height: 3,
+				Glyphs: map[rune]domain.Letter{
+					'I': letterFromRows([]string{"#", "#", "#"}),
+					'O': letterFromRows([]string{"##", "##", "##"}),
+				},
+			},
+		},
+		{
+			name:    "font without name should return error",
+			face:    &domain.FontFace{Height: 3},
+			wantErr: "font name is empty",
+		},
+		{
+			name:    "font higher than the calendar should return error",
+			face:    &domain.FontFace{Name: "test-high", Height: 8},
+			wantErr: "font test-high: height 8 is not in [1, 7]",
+		},
+		{
+			name:    "font without glyphs should return error",
+			face:    &domain.FontFace{Name: "test-empty", Height: 3},
+			wantErr: "font test-empty has no glyph",
+		},
+		{
+			name: "glyph of a wrong height should return error",
+			face: &domain.FontFace{
+				Name:   "test-wrong-height",
+				Height: 3,
+				Glyphs: map[rune]domain.Letter{'I': letterFromRows([]string{"#", "#"})},
+			},
+			wantErr: "font test-wrong-height: glyph 'I' has 2 rows, want 3",
+		},
+		{
+			name: "glyph of ragged rows should return error",
+			face: &domain.FontFace{
+				Name:   "test-ragged",
+				Height: 2,
+				Glyphs: map[rune]domain.Letter{'I': letterFromRows([]string{"#", "##"})},
+			},
+			wantErr: "font test-ragged: glyph 'I' has rows of different widths",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.face)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				_, ok := Lookup(tt.face.Name)
+				assert.False(t, ok)
+				return
+			}
+
+			assert.NoError(t, err)
+			_, ok := tt.face.Glyphs[' ']
+			assert.False(t, ok, "glyphs of the caller should be left as they are")
+			face, ok := Lookup(tt.face.Name)
+			assert.True(t, ok)
+			assert.Equal(t, letterFromRows([]string{".", ".", "."}), face.Glyphs[' '])
+
+			d := NewDictionary(tt.face.Name)
+			assert.Equal(t, 3, d.FontHeight())
+			assert.Equal(t, 2, d.FontWidth())
+			got, err := d.GetLetters("IO", 0, 0, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.Letter{face.Glyphs['I'], face.Glyphs['O']}, got)
+		})
+	}
+}
+
+func TestRegister_copiesKerning(t *testing.T) {
+	kerning := map[domain.KerningPair]int{{'L', 'T'}: -1}
+	assert.NoError(t, Register(&domain.FontFace{
+		Name:    "test-kerning-copy",
+		Height:  1,
+		Glyphs:  map[rune]domain.Letter{'L': {{1}}, 'T': {{1}}},
+		Kerning: kerning,
+	}))
+
+	// the kerning of the caller is changed after registering
+	kerning[domain.KerningPair{'L', 'T'}] = -5
+	face, ok := Lookup("test-kerning-copy")
+	assert.True(t, ok)
+	assert.Equal(t, map[domain.KerningPair]int{{'L', 'T'}: -1}, face.Kerning)
+}
+
+func TestFonts(t *testing.T) {
+	var names []domain.Font
+	for _, face := range Fonts() {
+		names = append(names, face.Name)
+	}
+
+	assert.Subset(t, names, []domain.Font{domain.Font55, domain.Font75})
+	assert.IsNonDecreasing(t, names)
+}
+
+func TestDictionary_unknownFont(t *testing.T) {
+	d := NewDictionary("not-registered")
+	assert.Equal(t, 0, d.FontHeight())
+	assert.Equal(t, 0, d.FontWidth())
+
+	_, err := d.GetLetters("A", 0, 0, 0)
+	assert.EqualError(t, err, "unknown font: not-registered")
+}
@@ -12,6 +12,52 @@ type GhGraphql struct {
 	C    *GraphClient
 }
 
+// DateRange is the days from From to To, both inclusive, the zero range is the trailing year of GitHub
+type DateRange struct {
+	From time.Time
+	To   time.Time
+	// Location is the timezone the days start in, UTC if nil
+	Location *time.Location
+}
+
+// IsZero tells if the range is the trailing year of GitHub
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+// Validate fails if the range has only one of From and To, or To is before From
+func (r DateRange) Validate() error {
+	if r.IsZero() {
+		return nil
+	}
+	if r.From.IsZero() || r.To.IsZero() {
+		return fmt.Errorf("date range should have both from and to, or neither for the trailing year: %s - %s",
+			r.From.Format(helper.DateFormat), r.To.Format(helper.DateFormat))
+	}
+	if r.From.After(r.To) {
+		return fmt.Errorf("invalid date range: %s > %s", r.From.Format(helper.DateFormat), r.To.Format(helper.DateFormat))
+	}
+	return nil
+}
+
+// Windows splits the valid range into windows of a year at most, as GitHub doesn't query more than a year at once
+func (r DateRange) Windows() []DateRange {
+	if r.IsZero() {
+		return []DateRange{r}
+	}
+
+	var windows []DateRange
+	for from := r.From; !from.After(r.To); {
+		to := from.AddDate(1, 0, -1)
+		if to.After(r.To) {
+			to = r.To
+		}
+		windows = append(windows, DateRange{From: from, To: to, Location: r.Location})
+		from = to.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
 func NewGhGraphql(config configs.GitInfo) *GhGraphql {
 	return &GhGraphql{
 		User: config.Author,
@@ -19,11 +65,45 @@ func NewGhGraphql(config configs.GitInfo) *GhGraphql {
 	}
 }
 
-func (g *GhGraphql) GetContributionCollection() (ContributionsCollectionResp, error) {
+// GetContributionCollection returns the contribution calendar of the days in the range, the range longer than
+// a year is queried window by window and the weeks are joined in order
+func (g *GhGraphql) GetContributionCollection(dateRange DateRange) (ContributionsCollectionResp, error) {
+	if err := dateRange.Validate(); err != nil {
+		return ContributionsCollectionResp{}, err
+	}
+
+	var joined ContributionsCollectionResp
+	for _, window := range dateRange.Windows() {
+		resp, err := g.getContributionCollection(window)
+		if err != nil {
+			return ContributionsCollectionResp{}, err
+		}
+
+		calendar := &joined.Data.User.ContributionsCollection.ContributionCalendar
+		windowCalendar := resp.Data.User.ContributionsCollection.ContributionCalendar
+		calendar.TotalContributions += windowCalendar.TotalContributions
+		calendar.Weeks = append(calendar.Weeks, windowCalendar.Weeks...)
+	}
+	return joined, nil
+}
+
+func (g *GhGraphql) getContributionCollection(window DateRange) (ContributionsCollectionResp, error) {
+	args := ""
+	if !window.IsZero() {
+		// from the start of the first day to the end of the last day in the timezone
+		loc := window.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		from := helper.InLocation(window.From, loc)
+		to := helper.InLocation(window.To.AddDate(0, 0, 1), loc).Add(-time.Second)
+		args = fmt.Sprintf(`(from: "%s", to: "%s")`, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
 	query := fmt.Sprintf(`
 	{
 		user(login: "%s") {
-			contributionsCollection {
+			contributionsCollection%s {
 				contributionCalendar {
 					totalContributions
 					weeks {
@@ -36,7 +116,7 @@ func (g *GhGraphql) GetContributionCollection() (ContributionsCollectionResp, er
 				}
 			}
 		}
-	}`, g.User)
+	}`, g.User, args)
 
 	var resp ContributionsCollectionResp
 	err := g.C.GraphQLRequest(query, &resp)
@@ -1,37 +1,142 @@
 package graphql
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 )
 
 func TestGhGraphql_GetContributionCollection(t *testing.T) {
-	type fields struct {
-		User string
-		C    *GraphClient
-	}
+	// the server answers every query with a day at its from date, and records the from/to arguments
+	var queried []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(request.Body).Decode(&req)
+		args := regexp.MustCompile(`from: "(\S+)", to: "(\S+)"`).FindStringSubmatch(req.Query)
+		date := "2023-06-18"
+		if args != nil {
+			queried = append(queried, args[1]+" "+args[2])
+			date = args[1][:10]
+		}
+		_, _ = fmt.Fprintf(writer, `{"data": {"user": {"contributionsCollection": {"contributionCalendar": {
+			"totalContributions": 1, "weeks": [{"contributionDays": [{"date": "%s", "contributionCount": 1}]}]}}}}}`, date)
+	}))
+	defer mockServer.Close()
+
 	tests := []struct {
-		name    string
-		fields  fields
-		want    ContributionsCollectionResp
-		wantErr bool
+		name      string
+		dateRange DateRange
+		wantDays  []string
+		wantQuery []string
+		wantErr   bool
 	}{
-		// TODO: Add test cases.
+		{
+			name:     "zero range should query the trailing year",
+			wantDays: []string{"2023-06-18"},
+		},
+		{
+			name:      "range of a year should be queried at once",
+			dateRange: DateRange{From: date(2019, 1, 1), To: date(2019, 12, 31)},
+			wantDays:  []string{"2019-01-01"},
+			wantQuery: []string{"2019-01-01T00:00:00Z 2019-12-31T23:59:59Z"},
+		},
+		{
+			name:      "range longer than a year should be split into windows",
+			dateRange: DateRange{From: date(2019, 3, 1), To: date(2021, 1, 10)},
+			wantDays:  []string{"2019-03-01", "2020-03-01"},
+			wantQuery: []string{
+				"2019-03-01T00:00:00Z 2020-02-29T23:59:59Z",
+				"2020-03-01T00:00:00Z 2021-01-10T23:59:59Z",
+			},
+		},
+		{
+			name: "days should start in the timezone across the DST transition",
+			dateRange: DateRange{From: date(2023, 3, 1), To: date(2023, 3, 31),
+				Location: mustLoadLocation(t, "America/New_York")},
+			wantDays:  []string{"2023-03-01"},
+			wantQuery: []string{"2023-03-01T00:00:00-05:00 2023-03-31T23:59:59-04:00"},
+		},
+		{
+			name:      "reversed range should return error",
+			dateRange: DateRange{From: date(2020, 1, 2), To: date(2020, 1, 1)},
+			wantErr:   true,
+		},
+		{
+			name:      "range with only from should return error",
+			dateRange: DateRange{From: date(2020, 1, 1)},
+			wantErr:   true,
+		},
+		{
+			name:      "range with only to should return error",
+			dateRange: DateRange{To: date(2020, 1, 1)},
+			wantErr:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			queried = nil
 			g := &GhGraphql{
-				User: tt.fields.User,
-				C:    tt.fields.C,
+				User: "painter",
+				C:    &GraphClient{Url: mockServer.URL, Client: &http.Client{}},
 			}
-			got, err := g.GetContributionCollection()
+			got, err := g.GetContributionCollection(tt.dateRange)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetContributionCollection() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetContributionCollection() got = %v, want %v", got, tt.want)
+			if tt.wantErr {
+				if len(queried) > 0 {
+					t.Errorf("GetContributionCollection() queried = %v, want none", queried)
+				}
+				return
+			}
+
+			var days []string
+			for _, week := range got.Data.User.ContributionsCollection.ContributionCalendar.Weeks {
+				for _, day := range week.ContributionDays {
+					days = append(days, day.Date)
+				}
+			}
+			if !reflect.DeepEqual(days, tt.wantDays) {
+				t.Errorf("GetContributionCollection() days = %v, want %v", days, tt.wantDays)
+			}
+			if !reflect.DeepEqual(queried, tt.wantQuery) {
+				t.Errorf("GetContributionCollection() queried = %v, want %v", queried, tt.wantQuery)
+			}
+			if total := got.Data.User.ContributionsCollection.ContributionCalendar.TotalContributions; total != len(days) {
+				t.Errorf("GetContributionCollection() total = %d, want %d", total, len(days))
 			}
 		})
 	}
 }
+
+func TestDateRange_Validate(t *testing.T) {
+	if err := (DateRange{From: date(2020, 1, 1)}).Validate(); err == nil ||
+		err.Error() != "date range should have both from and to, or neither for the trailing year: 2020-01-01 - 0001-01-01" {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (DateRange{To: date(2020, 1, 1)}).Validate(); err == nil ||
+		err.Error() != "date range should have both from and to, or neither for the trailing year: 0001-01-01 - 2020-01-01" {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (DateRange{}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil for the trailing year", err)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone %s is not available: %v", name, err)
+	}
+	return loc
+}
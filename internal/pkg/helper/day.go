@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+)
+
+// The days of the calendar are kept as the midnight in UTC, so they can be compared and stepped by 24 hours
+// whatever the timezone is, they are only converted to the timezone for the commit times.
+
+// Day returns the day of the time in the timezone, as the midnight in UTC
+func Day(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// InLocation returns the start of the day in the timezone, the first instant of the day if its midnight
+// is skipped by a DST transition
+func InLocation(day time.Time, loc *time.Location) time.Time {
+	y, m, d := day.Date()
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	// the midnight in a gap may be normalized to the previous day
+	for t.Day() != d {
+		t = t.Add(time.Hour)
+	}
+	return t
+}
+
+// ParseDay parses the day of the calendar, a date or a timestamp whose date is the day in the timezone of the
+// calendar, e.g. 2023-06-18 or 2023-06-18T00:00:00.000+08:00
+func ParseDay(s string) (time.Time, error) {
+	if len(s) < len(DateFormat) {
+		return time.Time{}, fmt.Errorf("invalid day: %q", s)
+	}
+	return time.Parse(DateFormat, s[:len(DateFormat)])
+}
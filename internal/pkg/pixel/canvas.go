@@ -0,0 +1,79 @@
+package pixel
+
+import (
+	"bufio"
+	"contribution-painter/internal/domain"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadCanvas reads a plain-text canvas file into a letter, every line is a day of the week from Sunday
+// to Saturday and every character is a week: '.' or ' ' is empty, '1' - '4' are levels and '#' is the
+// darkest level. Shorter lines are padded with empty dots.
+func LoadCanvas(path string) (domain.Letter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open canvas failed: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read canvas failed: %w", err)
+	}
+
+	// ignore trailing empty lines
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	canvas, err := parseCanvas(lines)
+	if err != nil {
+		return nil, fmt.Errorf("parse canvas %s failed: %w", path, err)
+	}
+	return canvas, nil
+}
+
+func parseCanvas(lines []string) (domain.Letter, error) {
+	if len(lines) != domain.CalendarHeight {
+		return nil, fmt.Errorf("canvas has %d lines, should be %d days of a week", len(lines), domain.CalendarHeight)
+	}
+
+	width := 0
+	for _, line := range lines {
+		if w := len([]rune(line)); w > width {
+			width = w
+		}
+	}
+	if width == 0 {
+		return nil, fmt.Errorf("canvas is empty")
+	}
+	if width > domain.CalendarWidth {
+		return nil, fmt.Errorf("canvas is %d columns wide, should be at most %d weeks", width, domain.CalendarWidth)
+	}
+
+	canvas := make(domain.Letter, len(lines))
+	for i, line := range lines {
+		canvas[i] = make([]uint, width)
+		for j, c := range []rune(line) {
+			switch {
+			case c == '.' || c == ' ':
+			case c >= '1' && c <= '4':
+				canvas[i][j] = uint(c - '0')
+			case c == '#':
+				canvas[i][j] = domain.MaxLevel
+			default:
+				return nil, fmt.Errorf("unknown dot %q at line %d, column %d", c, i+1, j+1)
+			}
+		}
+	}
+
+	return canvas, nil
+}
@@ -0,0 +1,69 @@
+package pixel
+
+import (
+	"contribution-painter/internal/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCanvas(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    domain.Letter
+		wantErr string
+	}{
+		{
+			name:    "canvas with less than 7 lines should return error",
+			content: "#...\n.1\n..2\n...3\n....4\n\n\n",
+			wantErr: "canvas has 5 lines, should be 7 days of a week",
+		},
+		{
+			name:    "canvas of 7 lines should be parsed into levels",
+			content: "#..\r\n.1.\r\n..2\r\n3\r\n 4\r\n\r\n..#\r\n\r\n",
+			want: domain.Letter{
+				{4, 0, 0},
+				{0, 1, 0},
+				{0, 0, 2},
+				{3, 0, 0},
+				{0, 4, 0},
+				{0, 0, 0},
+				{0, 0, 4},
+			},
+		},
+		{
+			name:    "unknown dot should return error",
+			content: "#\n#\n#\n#x\n#\n#\n#\n",
+			wantErr: `unknown dot 'x' at line 4, column 2`,
+		},
+		{
+			name:    "canvas wider than the calendar should return error",
+			content: strings.Repeat(strings.Repeat(".", 54)+"\n", 7),
+			wantErr: "canvas is 54 columns wide, should be at most 53 weeks",
+		},
+		{
+			name:    "canvas with more than 7 lines should return error",
+			content: strings.Repeat("\n", 7) + "x",
+			wantErr: `canvas has 8 lines, should be 7 days of a week`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "canvas.txt")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			got, err := LoadCanvas(path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
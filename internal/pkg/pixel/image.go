@@ -0,0 +1,88 @@
+package pixel
+
+import (
+	"contribution-painter/internal/domain"
+	"fmt"
+	"image"
+	_ "image/gif" // register GIF decoder
+	_ "image/png" // register PNG decoder
+	"math"
+	"os"
+)
+
+// LoadImage decodes a PNG or GIF file into a letter of the calendar height, the value of every dot is
+// the colour level mapped from the luminance, dark pixels get high levels and transparent pixels are empty.
+// Images larger than the calendar are downscaled to fit in it, keeping the aspect ratio.
+func LoadImage(path string) (domain.Letter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open image failed: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode image %s failed: %w", path, err)
+	}
+
+	return imageToLetter(img)
+}
+
+func imageToLetter(img image.Image) (domain.Letter, error) {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	if srcWidth == 0 || srcHeight == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+
+	width, height := fitCalendar(srcWidth, srcHeight)
+	letter := make(domain.Letter, height)
+	for y := 0; y < height; y++ {
+		letter[y] = make([]uint, width)
+		for x := 0; x < width; x++ {
+			// average the darkness of the source pixels covered by the dot
+			x0, x1 := x*srcWidth/width, (x+1)*srcWidth/width
+			y0, y1 := y*srcHeight/height, (y+1)*srcHeight/height
+			var sum float64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sum += darkness(img, bounds.Min.X+sx, bounds.Min.Y+sy)
+				}
+			}
+			darkness := sum / float64((x1-x0)*(y1-y0))
+			letter[y][x] = uint(math.Round(darkness * domain.MaxLevel))
+		}
+	}
+
+	return letter, nil
+}
+
+// fitCalendar returns the size of the image in dots, images higher or wider than the calendar are downscaled
+// by the same factor in both directions to keep the aspect ratio, a downscaled image lower than the calendar
+// is centered vertically when painted like any lower letter
+func fitCalendar(width, height int) (int, int) {
+	if width <= domain.CalendarWidth && height <= domain.CalendarHeight {
+		return width, height
+	}
+
+	scale := math.Min(float64(domain.CalendarWidth)/float64(width), float64(domain.CalendarHeight)/float64(height))
+	scaled := func(size, limit int) int {
+		return int(math.Min(float64(limit), math.Max(1, math.Round(float64(size)*scale))))
+	}
+	return scaled(width, domain.CalendarWidth), scaled(height, domain.CalendarHeight)
+}
+
+// darkness returns 1 - luminance of the pixel blended over white, in [0, 1]
+func darkness(img image.Image, x, y int) float64 {
+	r, g, b, a := img.At(x, y).RGBA()
+	if a == 0 {
+		return 0
+	}
+
+	// colors are alpha-premultiplied, blend over white background
+	white := float64(0xffff - a)
+	luminance := (0.2126*(float64(r)+white) + 0.7152*(float64(g)+white) + 0.0722*(float64(b)+white)) / 0xffff
+	return math.Max(0, math.Min(1, 1-luminance))
+}
@@ -0,0 +1,104 @@
+package pixel
+
+import (
+	"contribution-painter/internal/domain"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadImage(t *testing.T) {
+	dir := t.TempDir()
+
+	// a 3x7 gradient from white to black, the last column is transparent
+	gradient := image.NewNRGBA(image.Rect(0, 0, 3, 7))
+	for y := 0; y < 7; y++ {
+		gray := uint8(0xff - y*0xff/6)
+		gradient.SetNRGBA(0, y, color.NRGBA{R: gray, G: gray, B: gray, A: 0xff})
+		gradient.SetNRGBA(1, y, color.NRGBA{A: 0xff})
+		gradient.SetNRGBA(2, y, color.NRGBA{})
+	}
+	pngFile := filepath.Join(dir, "gradient.png")
+	writeImage(t, pngFile, func(f *os.File) error { return png.Encode(f, gradient) })
+
+	// a 28x14 GIF, black on the left half and white on the right half
+	halves := image.NewPaletted(image.Rect(0, 0, 28, 14), palette.Plan9)
+	for y := 0; y < 14; y++ {
+		for x := 0; x < 28; x++ {
+			if x < 14 {
+				halves.Set(x, y, color.Black)
+			} else {
+				halves.Set(x, y, color.White)
+			}
+		}
+	}
+	gifFile := filepath.Join(dir, "halves.gif")
+	writeImage(t, gifFile, func(f *os.File) error { return gif.Encode(f, halves, nil) })
+
+	t.Run("luminance should be mapped to levels", func(t *testing.T) {
+		got, err := LoadImage(pngFile)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Letter{
+			{0, 4, 0},
+			{1, 4, 0},
+			{1, 4, 0},
+			{2, 4, 0},
+			{3, 4, 0},
+			{3, 4, 0},
+			{4, 4, 0},
+		}, got)
+	})
+
+	t.Run("larger image should be downscaled to the calendar height", func(t *testing.T) {
+		got, err := LoadImage(gifFile)
+		assert.NoError(t, err)
+		assert.Len(t, got, domain.CalendarHeight)
+		for _, row := range got {
+			assert.Equal(t, []uint{4, 4, 4, 4, 4, 4, 4, 0, 0, 0, 0, 0, 0, 0}, row)
+		}
+	})
+
+	t.Run("not an image should return error", func(t *testing.T) {
+		textFile := filepath.Join(dir, "text.png")
+		assert.NoError(t, os.WriteFile(textFile, []byte("not an image"), 0o644))
+
+		_, err := LoadImage(textFile)
+		assert.Error(t, err)
+	})
+}
+
+func Test_fitCalendar(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{name: "image fits the calendar should keep its size", width: 53, height: 7, wantWidth: 53, wantHeight: 7},
+		{name: "lower image should keep its size", width: 10, height: 5, wantWidth: 10, wantHeight: 5},
+		{name: "higher image should keep the aspect ratio", width: 100, height: 70, wantWidth: 10, wantHeight: 7},
+		{name: "wider image should keep the aspect ratio", width: 200, height: 7, wantWidth: 53, wantHeight: 2},
+		{name: "wider and higher image should be scaled by the smaller ratio", width: 1000, height: 70, wantWidth: 53, wantHeight: 4},
+		{name: "very wide image should keep a row", width: 1000, height: 2, wantWidth: 53, wantHeight: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := fitCalendar(tt.width, tt.height)
+			assert.Equal(t, tt.wantWidth, width)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
+
+func writeImage(t *testing.T, path string, encode func(f *os.File) error) {
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	assert.NoError(t, encode(f))
+	assert.NoError(t, f.Close())
+}
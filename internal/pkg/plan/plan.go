@@ -0,0 +1,286 @@
+package plan
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/helper"
+	"contribution-painter/internal/pkg/stat"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the version of the plan format
+const Version = 2
+
+// Layer is the layer of the painting a commit belongs to
+type Layer string
+
+const (
+	LayerBackground Layer = "background"
+	LayerForeground Layer = "foreground"
+)
+
+// the trailers of the commits created by the painter
+const (
+	TrailerPlan  = "Painter-Plan"
+	TrailerDate  = "Painter-Date"
+	TrailerLayer = "Painter-Layer"
+)
+
+// Plan is the commits to paint by day, it is applied only if the calendar is still the same as its snapshot
+type Plan struct {
+	Version int `json:"version" yaml:"version"`
+	// ID is the short hash of the painting, every commit of the plan is tagged with it, so a re-run of
+	// the same painting only creates the commits which are not in the repo yet
+	ID         string    `json:"id" yaml:"id"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	ConfigHash string    `json:"config_hash" yaml:"config_hash"`
+	Author     Author    `json:"author" yaml:"author"`
+	// Calendar is the snapshot of the commits by day when the plan is made
+	Calendar map[string]int `json:"calendar" yaml:"calendar"`
+	// Commits is the commits to create by day and layer
+	Commits map[string]map[Layer]int `json:"commits" yaml:"commits"`
+}
+
+// LayerStat is the commits of a layer on a day
+type LayerStat struct {
+	stat.CommitStat
+	Layer Layer
+}
+
+// Author is the identity the commits are created with
+type Author struct {
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email" yaml:"email"`
+}
+
+// New creates a plan of the commits by layer from the config and the calendar, days with no commits to create
+// are left out
+func New(cfg configs.Configuration, calendar []stat.CommitStat, commits map[Layer][]stat.CommitStat) *Plan {
+	p := &Plan{
+		Version:    Version,
+		ID:         PaintingID(cfg),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		ConfigHash: ConfigHash(cfg),
+		Author:     Author{Name: cfg.GitInfo.Author, Email: cfg.GitInfo.Email},
+		Calendar:   make(map[string]int),
+		Commits:    make(map[string]map[Layer]int),
+	}
+
+	for _, cs := range calendar {
+		p.Calendar[cs.Date.Format(helper.DateFormat)] = cs.Commits
+	}
+	for layer, stats := range commits {
+		for _, cs := range stats {
+			if cs.Commits <= 0 {
+				continue
+			}
+			day := cs.Date.Format(helper.DateFormat)
+			if p.Commits[day] == nil {
+				p.Commits[day] = make(map[Layer]int)
+			}
+			p.Commits[day][layer] += cs.Commits
+		}
+	}
+
+	return p
+}
+
+// Total returns the number of the commits to create
+func (p *Plan) Total() int {
+	total := 0
+	for _, layers := range p.Commits {
+		for _, commits := range layers {
+			total += commits
+		}
+	}
+	return total
+}
+
+// Trailers returns the trailers of a commit of the plan in the layer on the day
+func (p *Plan) Trailers(date time.Time, layer Layer) string {
+	return fmt.Sprintf("%s: %s\n%s: %s\n%s: %s\n", TrailerPlan, p.ID, TrailerDate, date.Format(helper.DateFormat),
+		TrailerLayer, layer)
+}
+
+// ParseTrailers returns the trailers of the last paragraph of a commit message by their keys
+func ParseTrailers(message string) map[string]string {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	trailers := make(map[string]string)
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok || strings.ContainsAny(key, " \t") {
+			return nil
+		}
+		trailers[key] = strings.TrimSpace(value)
+	}
+	return trailers
+}
+
+// ConfigHash returns the hash of the config the plan is made from, the secrets and the dry run are left out
+// as they don't change the painting
+func ConfigHash(cfg configs.Configuration) string {
+	cfg.GitInfo.GhToken = ""
+	cfg.GitInfo.SSHKeyPassphrase = ""
+	cfg.Rewriter.DryRun = false
+	b, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// PaintingID returns the short hash of the part of the config the painting is made from, i.e. the calendar,
+// the timezone, the source, the letters and their font, the levels and the email the commits count for.
+// How the commits are created and pushed is left out, e.g. the commit times, the content and the repo,
+// so the commits of a painting are still recognized after changing them
+func PaintingID(cfg configs.Configuration) string {
+	r := cfg.Rewriter
+	painting := struct {
+		Email    string
+		Rewriter configs.Rewriter
+	}{
+		Email: cfg.GitInfo.Email,
+		Rewriter: configs.Rewriter{
+			Year:                    r.Year,
+			StartDate:               r.StartDate,
+			Timezone:                r.Timezone,
+			Source:                  r.Source,
+			ImageFile:               r.ImageFile,
+			CanvasFile:              r.CanvasFile,
+			BackgroundCommitsPerDay: r.BackgroundCommitsPerDay,
+			ForegroundCommitsPerDay: r.ForegroundCommitsPerDay,
+			CommitsPerLevel:         r.CommitsPerLevel,
+			ForegroundLevel:         r.ForegroundLevel,
+			ShadowLevel:             r.ShadowLevel,
+			TargetLetters:           r.TargetLetters,
+			LeadingColumns:          r.LeadingColumns,
+			TrailingColumns:         r.TrailingColumns,
+			LetterSpacing:           r.LetterSpacing,
+			Font:                    r.Font,
+			FontFile:                r.FontFile,
+			Case:                    r.Case,
+			Proportional:            r.Proportional,
+			Kerning:                 r.Kerning,
+		},
+	}
+	b, _ := json.Marshal(painting)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// CommitStats returns the commits to create by day and layer, ordered by date, the background comes first
+func (p *Plan) CommitStats() ([]LayerStat, error) {
+	var stats []LayerStat
+	for day, layers := range p.Commits {
+		date, err := time.Parse(helper.DateFormat, day)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date of commits: %w", err)
+		}
+		for layer, commits := range layers {
+			stats = append(stats, LayerStat{CommitStat: stat.CommitStat{Date: date, Commits: commits}, Layer: layer})
+		}
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if !stats[i].Date.Equal(stats[j].Date) {
+			return stats[i].Date.Before(stats[j].Date)
+		}
+		return stats[i].Layer < stats[j].Layer
+	})
+	return stats, nil
+}
+
+// SnapshotRange returns the date range of the calendar snapshot, from its first day to its last day, so the
+// calendar compared against it covers the same days, zero if the snapshot is empty
+func (p *Plan) SnapshotRange() (graphql.DateRange, error) {
+	var dateRange graphql.DateRange
+	for day := range p.Calendar {
+		date, err := time.Parse(helper.DateFormat, day)
+		if err != nil {
+			return graphql.DateRange{}, fmt.Errorf("invalid date of calendar: %w", err)
+		}
+		if dateRange.From.IsZero() || date.Before(dateRange.From) {
+			dateRange.From = date
+		}
+		if date.After(dateRange.To) {
+			dateRange.To = date
+		}
+	}
+	return dateRange, nil
+}
+
+// CheckDrift fails if the commits of any day in the snapshot are changed in the calendar,
+// days out of the snapshot are not compared as the calendar moves on every day
+func (p *Plan) CheckDrift(calendar []stat.CommitStat) error {
+	var drifted []string
+	for _, cs := range calendar {
+		day := cs.Date.Format(helper.DateFormat)
+		snapshot, ok := p.Calendar[day]
+		if ok && snapshot != cs.Commits {
+			drifted = append(drifted, fmt.Sprintf("%s: %d -> %d", day, snapshot, cs.Commits))
+		}
+	}
+
+	if len(drifted) > 0 {
+		return fmt.Errorf("calendar has drifted from the snapshot, %s", strings.Join(drifted, ", "))
+	}
+	return nil
+}
+
+// Save writes the plan to path, in YAML if the extension is .yaml or .yml, otherwise in JSON
+func (p *Plan) Save(path string) error {
+	var (
+		b   []byte
+		err error
+	)
+	if isYAML(path) {
+		b, err = yaml.Marshal(p)
+	} else {
+		b, err = json.MarshalIndent(p, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("marshal plan failed: %w", err)
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Load reads the plan from path, in YAML if the extension is .yaml or .yml, otherwise in JSON
+func Load(path string) (*Plan, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{}
+	if isYAML(path) {
+		err = yaml.Unmarshal(b, p)
+	} else {
+		err = json.Unmarshal(b, p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal plan failed: %w", err)
+	}
+
+	if p.Version != Version {
+		return nil, fmt.Errorf("unsupported plan version: %d", p.Version)
+	}
+	return p, nil
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,143 @@
+package plan
+
+import (
+	"contribution-painter/configs"
+	"contribution-painter/internal/pkg/graphql"
+	"contribution-painter/internal/pkg/stat"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(d int) time.Time {
+	return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func mockPlan() *Plan {
+	cfg := configs.Configuration{GitInfo: configs.GitInfo{Author: "painter", Email: "painter@example.com"}}
+	calendar := []stat.CommitStat{{Date: day(1), Commits: 0}, {Date: day(2), Commits: 3}}
+	commits := map[Layer][]stat.CommitStat{
+		LayerBackground: {{Date: day(2), Commits: 1}, {Date: day(1), Commits: 2}, {Date: day(3)}},
+		LayerForeground: {{Date: day(2), Commits: 1}, {Date: day(2), Commits: 1}},
+	}
+	return New(cfg, calendar, commits)
+}
+
+func TestNew(t *testing.T) {
+	p := mockPlan()
+
+	assert.Equal(t, Version, p.Version)
+	assert.Equal(t, Author{Name: "painter", Email: "painter@example.com"}, p.Author)
+	assert.Equal(t, map[string]int{"2023-01-01": 0, "2023-01-02": 3}, p.Calendar)
+	assert.Equal(t, map[string]map[Layer]int{
+		"2023-01-01": {LayerBackground: 2},
+		"2023-01-02": {LayerBackground: 1, LayerForeground: 2},
+	}, p.Commits)
+	assert.Equal(t, 5, p.Total())
+	assert.Len(t, p.ID, 16)
+
+	stats, err := p.CommitStats()
+	assert.NoError(t, err)
+	assert.Equal(t, []LayerStat{
+		{CommitStat: stat.CommitStat{Date: day(1), Commits: 2}, Layer: LayerBackground},
+		{CommitStat: stat.CommitStat{Date: day(2), Commits: 1}, Layer: LayerBackground},
+		{CommitStat: stat.CommitStat{Date: day(2), Commits: 2}, Layer: LayerForeground},
+	}, stats)
+
+	// the same painting on another calendar is the same plan
+	cfg := configs.Configuration{GitInfo: configs.GitInfo{Author: "painter", Email: "painter@example.com"}}
+	assert.Equal(t, p.ID, New(cfg, nil, nil).ID)
+}
+
+func TestTrailers(t *testing.T) {
+	p := mockPlan()
+	message := "Arbitrary commit #1\n\n" + p.Trailers(day(2), LayerForeground)
+
+	assert.Equal(t, map[string]string{
+		TrailerPlan:  p.ID,
+		TrailerDate:  "2023-01-02",
+		TrailerLayer: "foreground",
+	}, ParseTrailers(message))
+	assert.Nil(t, ParseTrailers("Arbitrary commit #1"))
+	assert.Nil(t, ParseTrailers("fix typo\n\nthe second paragraph isn't trailers"))
+}
+
+func TestPaintingID(t *testing.T) {
+	cfg := configs.Configuration{
+		GitInfo:  configs.GitInfo{Email: "painter@example.com"},
+		Rewriter: configs.Rewriter{TargetLetters: "HI", Timezone: "Asia/Tokyo"},
+	}
+	other := cfg
+	other.GitInfo.LocalPath = "painting"
+	other.GitInfo.Backup = "ref"
+	other.Rewriter.TimeSeed = 42
+	other.Rewriter.Content = "file"
+	assert.Equal(t, PaintingID(cfg), PaintingID(other), "how the commits are created should be left out")
+	assert.NotEqual(t, ConfigHash(cfg), ConfigHash(other))
+
+	other.Rewriter.Timezone = "UTC"
+	assert.NotEqual(t, PaintingID(cfg), PaintingID(other))
+	other = cfg
+	other.Rewriter.TargetLetters = "HELLO"
+	assert.NotEqual(t, PaintingID(cfg), PaintingID(other))
+}
+
+func TestConfigHash(t *testing.T) {
+	cfg := configs.Configuration{GitInfo: configs.GitInfo{GhToken: "secret"}, Rewriter: configs.Rewriter{TargetLetters: "HI"}}
+	other := cfg
+	other.GitInfo.GhToken = "another"
+
+	assert.Equal(t, ConfigHash(cfg), ConfigHash(other), "token should be left out")
+	other.Rewriter.DryRun = true
+	assert.Equal(t, ConfigHash(cfg), ConfigHash(other), "dry run should be left out")
+	other.Rewriter.TargetLetters = "HELLO"
+	assert.NotEqual(t, ConfigHash(cfg), ConfigHash(other))
+}
+
+func TestPlan_SnapshotRange(t *testing.T) {
+	dateRange, err := mockPlan().SnapshotRange()
+	assert.NoError(t, err)
+	assert.Equal(t, graphql.DateRange{From: day(1), To: day(2)}, dateRange)
+
+	dateRange, err = (&Plan{}).SnapshotRange()
+	assert.NoError(t, err)
+	assert.True(t, dateRange.IsZero())
+}
+
+func TestPlan_CheckDrift(t *testing.T) {
+	p := mockPlan()
+
+	// the calendar moved on by a day
+	assert.NoError(t, p.CheckDrift([]stat.CommitStat{{Date: day(2), Commits: 3}, {Date: day(3), Commits: 5}}))
+	assert.EqualError(t, p.CheckDrift([]stat.CommitStat{{Date: day(1), Commits: 1}, {Date: day(2), Commits: 3}}),
+		"calendar has drifted from the snapshot, 2023-01-01: 0 -> 1")
+}
+
+func TestPlan_SaveAndLoad(t *testing.T) {
+	for _, name := range []string{"plan.json", "plan.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			p := mockPlan()
+			assert.NoError(t, p.Save(path))
+
+			loaded, err := Load(path)
+			assert.NoError(t, err)
+			assert.Equal(t, p.ID, loaded.ID)
+			assert.Equal(t, p.Commits, loaded.Commits)
+			assert.Equal(t, p.Calendar, loaded.Calendar)
+			assert.True(t, p.CreatedAt.Equal(loaded.CreatedAt))
+			assert.Equal(t, p.ConfigHash, loaded.ConfigHash)
+		})
+	}
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version": 1}`), 0o644))
+
+	_, err := Load(path)
+	assert.EqualError(t, err, "unsupported plan version: 1")
+}
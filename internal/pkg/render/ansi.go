@@ -0,0 +1,168 @@
+package render
+
+import (
+	"contribution-painter/internal/domain"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+)
+
+// ColorMode is the colour capability of the terminal
+type ColorMode int
+
+const (
+	TrueColor ColorMode = iota // 24-bit colours
+	Color256                   // the xterm 256 colours
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiCell    = "■ "
+	ansiGutter  = 4 // width of the weekday labels
+	ansiColumns = 2 // width of a week
+	ansiGap     = "    "
+)
+
+// cubeLevels are the values of the 6x6x6 colour cube of the xterm 256 colours
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// DetectColorMode returns TrueColor if the terminal announces 24-bit colours in COLORTERM, otherwise Color256
+func DetectColorMode() ColorMode {
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	default:
+		return Color256
+	}
+}
+
+// ANSI writes the days as a contribution calendar to the terminal, every day is coloured by its level
+func ANSI(w io.Writer, days []Day, theme Theme, mode ColorMode) error {
+	lines, _ := ansiLines(days, theme, mode)
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// ANSICompare writes the calendars before and after the painting side by side to the terminal
+func ANSICompare(w io.Writer, before, after []Day, theme Theme, mode ColorMode) error {
+	left, leftWidth := ansiLines(before, theme, mode)
+	right, _ := ansiLines(after, theme, mode)
+
+	text := fgColor(theme.Text, mode)
+	var sb strings.Builder
+	sb.WriteString(text + padRight("before", leftWidth) + ansiGap + "after" + ansiReset + "\n")
+	for i := 0; i < len(left) || i < len(right); i++ {
+		l, r := strings.Repeat(" ", leftWidth), ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		sb.WriteString(l + ansiGap + r + "\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ansiLines returns the lines of the calendar with the month labels on the top and the weekday labels
+// on the left, and the visible width shared by every line
+func ansiLines(days []Day, theme Theme, mode ColorMode) ([]string, int) {
+	l := newLayout(days)
+	if len(l.cells) == 0 {
+		return nil, 0
+	}
+
+	weeks := (l.width - leftMargin - padding) / cellStep
+	width := ansiGutter + weeks*ansiColumns
+
+	header := []rune(strings.Repeat(" ", width))
+	for _, month := range l.months {
+		copy(header[ansiGutter+(month.x-leftMargin)/cellStep*ansiColumns:], []rune(month.text))
+	}
+
+	rows := make([][]string, domain.CalendarHeight)
+	for i := range rows {
+		rows[i] = make([]string, weeks)
+		for j := range rows[i] {
+			rows[i][j] = strings.Repeat(" ", ansiColumns)
+		}
+	}
+	for _, c := range l.cells {
+		week, weekday := (c.x-leftMargin)/cellStep, (c.y-topMargin)/cellStep
+		rows[weekday][week] = fgColor(theme.Levels[c.day.Level], mode) + ansiCell + ansiReset
+	}
+
+	text := fgColor(theme.Text, mode)
+	lines := []string{text + string(header) + ansiReset}
+	for i, row := range rows {
+		gutter := strings.Repeat(" ", ansiGutter)
+		for _, weekday := range l.weekdays {
+			if (weekday.y-topMargin)/cellStep == i {
+				gutter = padRight(weekday.text, ansiGutter)
+			}
+		}
+		lines = append(lines, text+gutter+ansiReset+strings.Join(row, ""))
+	}
+	return lines, width
+}
+
+// fgColor returns the escape sequence of the foreground colour of the form #rrggbb
+func fgColor(hex string, mode ColorMode) string {
+	c := parseHex(hex)
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", to256(c))
+}
+
+// to256 returns the nearest of the xterm 256 colours, from the colour cube or the grey ramp
+func to256(c color.RGBA) int {
+	nearestCube := func(v uint8) int {
+		nearest := 0
+		for i, level := range cubeLevels {
+			if abs(int(v)-level) < abs(int(v)-cubeLevels[nearest]) {
+				nearest = i
+			}
+		}
+		return nearest
+	}
+	r, g, b := nearestCube(c.R), nearestCube(c.G), nearestCube(c.B)
+	cube := color.RGBA{R: uint8(cubeLevels[r]), G: uint8(cubeLevels[g]), B: uint8(cubeLevels[b])}
+
+	grey := (int(c.R) + int(c.G) + int(c.B)) / 3
+	greyIndex := (grey - 8) / 10
+	if greyIndex < 0 {
+		greyIndex = 0
+	} else if greyIndex > 23 {
+		greyIndex = 23
+	}
+	greyValue := uint8(8 + greyIndex*10)
+
+	if distance(c, color.RGBA{R: greyValue, G: greyValue, B: greyValue}) < distance(c, cube) {
+		return 232 + greyIndex
+	}
+	return 16 + 36*r + 6*g + b
+}
+
+func distance(a, b color.RGBA) int {
+	dr, dg, db := int(a.R)-int(b.R), int(a.G)-int(b.G), int(a.B)-int(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
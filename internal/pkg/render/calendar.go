@@ -0,0 +1,86 @@
+package render
+
+import (
+	"contribution-painter/internal/domain"
+	"time"
+)
+
+const (
+	cellSize    = 10
+	cellStep    = 13
+	leftMargin  = 30
+	topMargin   = 20
+	padding     = 10
+	minLabelGap = 3 // min weeks between two month labels
+)
+
+// Day is a day of the contribution calendar with its colour level
+type Day struct {
+	Date  time.Time
+	Count int
+	Level int
+}
+
+type cell struct {
+	x, y int
+	day  Day
+}
+
+type label struct {
+	x, y int
+	text string
+}
+
+// layout places the days sorted by date on a grid of weeks like the GitHub profile,
+// with the month labels on the top and the weekday labels on the left
+type layout struct {
+	width, height int
+	cells         []cell
+	months        []label
+	weekdays      []label
+}
+
+func newLayout(days []Day) layout {
+	l := layout{}
+	if len(days) == 0 {
+		return l
+	}
+
+	first := days[0].Date.AddDate(0, 0, -int(days[0].Date.Weekday()))
+	weeks := 0
+	lastMonthWeek := -minLabelGap
+	for i, day := range days {
+		week := int(day.Date.Sub(first).Hours()/24) / 7
+		x, y := leftMargin+week*cellStep, topMargin+int(day.Date.Weekday())*cellStep
+		l.cells = append(l.cells, cell{x: x, y: y, day: day})
+
+		// label a month at the week it starts in, the label of the partial first month gives way to the
+		// next month if they are too close, other labels too close to the previous one are skipped
+		if i == 0 || day.Date.Day() == 1 {
+			month := label{x: x, y: topMargin - 8, text: day.Date.Format("Jan")}
+			switch {
+			case week-lastMonthWeek >= minLabelGap:
+				l.months = append(l.months, month)
+				lastMonthWeek = week
+			case len(l.months) == 1 && days[0].Date.Day() != 1:
+				l.months[0] = month
+				lastMonthWeek = week
+			}
+		}
+		if week+1 > weeks {
+			weeks = week + 1
+		}
+	}
+
+	for _, weekday := range []time.Weekday{time.Monday, time.Wednesday, time.Friday} {
+		l.weekdays = append(l.weekdays, label{
+			x:    0,
+			y:    topMargin + int(weekday)*cellStep + cellSize - 2,
+			text: weekday.String()[:3],
+		})
+	}
+
+	l.width = leftMargin + weeks*cellStep + padding
+	l.height = topMargin + domain.CalendarHeight*cellStep + padding
+	return l
+}
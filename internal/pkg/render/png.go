@@ -0,0 +1,48 @@
+package render
+
+import (
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/dict"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// PNG writes the days as a contribution calendar in PNG, the labels are drawn in the 5*5 font
+func PNG(w io.Writer, days []Day, theme Theme) error {
+	l := newLayout(days)
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(parseHex(theme.Background)), image.Point{}, draw.Src)
+
+	for _, c := range l.cells {
+		fill := image.NewUniform(parseHex(theme.Levels[c.day.Level]))
+		draw.Draw(img, image.Rect(c.x, c.y, c.x+cellSize, c.y+cellSize), fill, image.Point{}, draw.Src)
+	}
+
+	labelDict := dict.NewDictionary(domain.Font55)
+	text := parseHex(theme.Text)
+	for _, lb := range append(l.months, l.weekdays...) {
+		letters, err := labelDict.GetLetters(strings.ToUpper(lb.text), 1, 0, 0)
+		if err != nil {
+			return fmt.Errorf("get letters of label %s failed: %w", lb.text, err)
+		}
+		drawLetter(img, domain.Letters(letters).Compose(), lb.x, lb.y-len(letters[0]), text)
+	}
+
+	return png.Encode(w, img)
+}
+
+// drawLetter draws the filled dots of the letter as pixels with its top left corner at (x, y)
+func drawLetter(img *image.RGBA, letter domain.Letter, x, y int, c color.Color) {
+	for i, row := range letter {
+		for j, dot := range row {
+			if dot != 0 {
+				img.Set(x+j, y+i, c)
+			}
+		}
+	}
+}
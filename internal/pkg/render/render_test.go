@@ -0,0 +1,114 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockDays returns the days from 2023-01-25(Wednesday) to 2023-02-14 with levels 0 - 4 in turn
+func mockDays() []Day {
+	var days []Day
+	start := time.Date(2023, 1, 25, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 21; i++ {
+		days = append(days, Day{Date: start.AddDate(0, 0, i), Count: i, Level: i % 5})
+	}
+	return days
+}
+
+func Test_newLayout(t *testing.T) {
+	l := newLayout(mockDays())
+
+	assert.Len(t, l.cells, 21)
+	assert.Equal(t, cell{x: leftMargin, y: topMargin + 3*cellStep, day: mockDays()[0]}, l.cells[0])
+	// 2023-02-01 is the Wednesday of the second week, too close to the label of the partial January
+	assert.Equal(t, []label{{x: leftMargin + cellStep, y: topMargin - 8, text: "Feb"}}, l.months)
+	assert.Len(t, l.weekdays, 3)
+	assert.Equal(t, leftMargin+4*cellStep+padding, l.width)
+	assert.Equal(t, topMargin+7*cellStep+padding, l.height)
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := SVG(&buf, mockDays(), ThemeDark)
+	assert.NoError(t, err)
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Equal(t, 21, strings.Count(svg, "<title>"))
+	assert.Contains(t, svg, `fill="#39d353"><title>4 contributions on 2023-01-29</title>`)
+	assert.Contains(t, svg, `>Feb</text>`)
+	assert.Contains(t, svg, `>Mon</text>`)
+}
+
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	err := PNG(&buf, mockDays(), ThemeLight)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	l := newLayout(mockDays())
+	assert.Equal(t, l.width, img.Bounds().Dx())
+	assert.Equal(t, l.height, img.Bounds().Dy())
+
+	// 2023-01-29 is the Sunday of the second week in level 4
+	assert.Equal(t, parseHex(ThemeLight.Levels[4]), color.RGBAModel.Convert(img.At(leftMargin+cellStep+1, topMargin+1)))
+}
+
+func TestThemeByName(t *testing.T) {
+	theme, err := ThemeByName("dark")
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeDark, theme)
+
+	_, err = ThemeByName("sepia")
+	assert.EqualError(t, err, "unknown theme: sepia")
+}
+
+func TestANSI(t *testing.T) {
+	var buf bytes.Buffer
+	err := ANSI(&buf, mockDays(), ThemeDark, TrueColor)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 1+7)
+	assert.Contains(t, lines[0], "      Feb")
+	// 2023-01-29 is the Sunday of the second week in level 4
+	assert.True(t, strings.HasPrefix(lines[1], "\x1b[38;2;125;133;144m    \x1b[0m  \x1b[38;2;57;211;83m■ \x1b[0m"), lines[1])
+	assert.Contains(t, lines[2], "Mon")
+	assert.Equal(t, 21, strings.Count(buf.String(), "■"))
+}
+
+func TestANSICompare(t *testing.T) {
+	var buf bytes.Buffer
+	err := ANSICompare(&buf, mockDays(), mockDays(), ThemeLight, Color256)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 1+1+7)
+	assert.Equal(t, "\x1b[38;5;59mbefore          after\x1b[0m", lines[0])
+	assert.Equal(t, 42, strings.Count(buf.String(), "■"))
+	assert.NotContains(t, buf.String(), "38;2;")
+}
+
+func Test_to256(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want int
+	}{
+		{hex: "#000000", want: 16},
+		{hex: "#ff0000", want: 196},
+		{hex: "#808080", want: 244},
+		{hex: "#39d353", want: 77},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			assert.Equal(t, tt.want, to256(parseHex(tt.hex)))
+		})
+	}
+}
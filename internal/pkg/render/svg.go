@@ -0,0 +1,35 @@
+package render
+
+import (
+	"contribution-painter/internal/pkg/helper"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SVG writes the days as a contribution calendar in SVG
+func SVG(w io.Writer, days []Day, theme Theme) error {
+	l := newLayout(days)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		l.width, l.height, l.width, l.height))
+	sb.WriteString(fmt.Sprintf(`<rect width="100%%" height="100%%" fill="%s"/>`+"\n", theme.Background))
+	sb.WriteString(fmt.Sprintf(`<g font-family="-apple-system, BlinkMacSystemFont, Segoe UI, Helvetica, Arial, sans-serif" font-size="9" fill="%s">`+"\n",
+		theme.Text))
+	for _, lb := range append(l.months, l.weekdays...) {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d">%s</text>`+"\n", lb.x, lb.y, lb.text))
+	}
+	sb.WriteString("</g>\n")
+
+	for _, c := range l.cells {
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="2" ry="2" fill="%s">`,
+			c.x, c.y, cellSize, cellSize, theme.Levels[c.day.Level]))
+		sb.WriteString(fmt.Sprintf(`<title>%d contributions on %s</title></rect>`+"\n",
+			c.day.Count, c.day.Date.Format(helper.DateFormat)))
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Theme is the colour palette of the contribution calendar
+type Theme struct {
+	Name       string
+	Background string
+	Text       string
+	// Levels are the colours of level 0 - 4 from light to dark
+	Levels [5]string
+}
+
+var (
+	ThemeLight = Theme{
+		Name:       "light",
+		Background: "#ffffff",
+		Text:       "#57606a",
+		Levels:     [5]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"},
+	}
+	ThemeDark = Theme{
+		Name:       "dark",
+		Background: "#0d1117",
+		Text:       "#7d8590",
+		Levels:     [5]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"},
+	}
+)
+
+// Themes returns all the themes
+func Themes() []Theme {
+	return []Theme{ThemeLight, ThemeDark}
+}
+
+// ThemeByName returns the theme with the given name
+func ThemeByName(name string) (Theme, error) {
+	for _, theme := range Themes() {
+		if theme.Name == name {
+			return theme, nil
+		}
+	}
+	return Theme{}, fmt.Errorf("unknown theme: %s", name)
+}
+
+// parseHex parses a colour of the form #rrggbb
+func parseHex(hex string) color.RGBA {
+	var r, g, b uint8
+	_, _ = fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b)
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+const defaultSSHUser = "git"
+
+// Credentials are the secrets to choose the auth method of a repo from
+type Credentials struct {
+	// Token is the token of HTTPS remotes, HTTPS remotes are anonymous without it
+	Token string
+	// SSHKeyFile is the private key of SSH remotes, the ssh-agent is used without it
+	SSHKeyFile string
+	// SSHKeyPassphrase decrypts SSHKeyFile if it is encrypted
+	SSHKeyPassphrase string
+}
+
+// NewAuthMethod returns the auth method by the scheme of the repo url, which is shared by clone and push:
+// a key file or the ssh-agent for SSH remotes, the token for HTTP(S) remotes, and nil for anonymous access
+// including local remotes
+func NewAuthMethod(repoUrl string, c Credentials) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(repoUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repo url: %w", err)
+	}
+
+	switch endpoint.Protocol {
+	case "ssh":
+		user := endpoint.User
+		if user == "" {
+			user = defaultSSHUser
+		}
+		if c.SSHKeyFile != "" {
+			keyFile, err := expandHome(c.SSHKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			auth, err := ssh.NewPublicKeysFromFile(user, keyFile, c.SSHKeyPassphrase)
+			if err != nil {
+				return nil, fmt.Errorf("load ssh key %s failed: %w", c.SSHKeyFile, err)
+			}
+			return auth, nil
+		}
+		auth, err := ssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("connect to ssh-agent failed: %w", err)
+		}
+		return auth, nil
+	case "http", "https":
+		if c.Token == "" {
+			return nil, nil
+		}
+		return &http.BasicAuth{
+			Username: "token",
+			Password: c.Token,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// expandHome replaces the leading ~ of the path with the home directory like the shell does,
+// e.g. ~/.ssh/id_ed25519
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("expand %s failed: %w", path, err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
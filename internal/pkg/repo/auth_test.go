@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+)
+
+// newKeyFile writes a private key to a file, encrypted by the passphrase if it is set
+func newKeyFile(t *testing.T, passphrase string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if passphrase != "" {
+		// the legacy PEM encryption is deprecated, but still supported by ssh keys
+		block, err = x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(passphrase), x509.PEMCipherAES256)
+		assert.NoError(t, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "id_rsa")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	return path
+}
+
+func TestNewAuthMethod(t *testing.T) {
+	keyFile := newKeyFile(t, "")
+	encryptedKeyFile := newKeyFile(t, "passphrase")
+	t.Setenv("SSH_AUTH_SOCK", "")
+	t.Setenv("HOME", filepath.Dir(keyFile))
+
+	tests := []struct {
+		name     string
+		repoUrl  string
+		c        Credentials
+		wantUser string
+		wantAuth interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "https with token",
+			repoUrl:  "https://github.com/qct/painting.git",
+			c:        Credentials{Token: "secret"},
+			wantAuth: &http.BasicAuth{Username: "token", Password: "secret"},
+		},
+		{
+			name:    "https without token is anonymous",
+			repoUrl: "https://github.com/qct/painting.git",
+		},
+		{
+			name:    "file is anonymous",
+			repoUrl: "file:///tmp/painting.git",
+			c:       Credentials{Token: "secret"},
+		},
+		{
+			name:     "scp-like ssh with key file",
+			repoUrl:  "git@github.com:qct/painting.git",
+			c:        Credentials{SSHKeyFile: keyFile},
+			wantUser: "git",
+		},
+		{
+			name:     "ssh with key file in the home directory",
+			repoUrl:  "git@github.com:qct/painting.git",
+			c:        Credentials{SSHKeyFile: "~/id_rsa"},
+			wantUser: "git",
+		},
+		{
+			name:     "ssh with encrypted key file",
+			repoUrl:  "ssh://painter@example.com/painting.git",
+			c:        Credentials{SSHKeyFile: encryptedKeyFile, SSHKeyPassphrase: "passphrase"},
+			wantUser: "painter",
+		},
+		{
+			name:    "ssh with wrong passphrase should return error",
+			repoUrl: "ssh://example.com/painting.git",
+			c:       Credentials{SSHKeyFile: encryptedKeyFile, SSHKeyPassphrase: "wrong"},
+			wantErr: true,
+		},
+		{
+			name:    "ssh without key file and ssh-agent should return error",
+			repoUrl: "ssh://example.com/painting.git",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewAuthMethod(tt.repoUrl, tt.c)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			switch {
+			case tt.wantUser != "":
+				assert.IsType(t, &ssh.PublicKeys{}, auth)
+				assert.Equal(t, tt.wantUser, auth.(*ssh.PublicKeys).User)
+			case tt.wantAuth != nil:
+				assert.Equal(t, tt.wantAuth, auth)
+			default:
+				assert.Nil(t, auth)
+			}
+		})
+	}
+}
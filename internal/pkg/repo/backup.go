@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BackupRefPrefix is the prefix of the refs on the remote the original branches are backed up to
+	BackupRefPrefix = "refs/painter-backup/"
+
+	bundleSignature = "# v2 git bundle\n"
+	// the message of the commit backed up in place of a branch the remote didn't have
+	absentMessage = "painter-backup: the branch didn't exist on the remote\n"
+)
+
+// RemoteBranch returns the branch on the remote as it is before pushing, nil if the remote has no such branch,
+// the branch of HEAD if branch is empty
+func RemoteBranch(r *git.Repository, auth transport.AuthMethod, branch string) (*plumbing.Reference, error) {
+	err := r.Fetch(&git.FetchOptions{RemoteName: git.DefaultRemoteName, Auth: auth, Force: true})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("failed to fetch remote: %w", err)
+	}
+
+	return TrackedBranch(r, branch)
+}
+
+// TrackedBranch returns the branch on the remote as it was last fetched, nil if the remote had no such branch,
+// the branch of HEAD if branch is empty
+func TrackedBranch(r *git.Repository, branch string) (*plumbing.Reference, error) {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := r.Reference(plumbing.NewRemoteReferenceName(git.DefaultRemoteName, name.Short()), false)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote branch: %w", err)
+	}
+	return plumbing.NewHashReference(name, remote.Hash()), nil
+}
+
+// AbsentBranch returns the branch pointing to a marker commit recording that the remote has no such branch,
+// which is backed up like a branch and makes RestoreBranch delete the branch, the branch of HEAD if branch is empty
+func AbsentBranch(r *git.Repository, branch string) (*plumbing.Reference, error) {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := r.Storer.NewEncodedObject()
+	if err = (&object.Tree{}).Encode(tree); err != nil {
+		return nil, fmt.Errorf("failed to encode tree: %w", err)
+	}
+	treeHash, err := r.Storer.SetEncodedObject(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store tree: %w", err)
+	}
+
+	signature := object.Signature{Name: "contribution-painter", When: time.Now()}
+	marker := &object.Commit{Author: signature, Committer: signature, Message: absentMessage, TreeHash: treeHash}
+	obj := r.Storer.NewEncodedObject()
+	if err = marker.Encode(obj); err != nil {
+		return nil, fmt.Errorf("failed to encode commit: %w", err)
+	}
+	hash, err := r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store commit: %w", err)
+	}
+	return plumbing.NewHashReference(name, hash), nil
+}
+
+// BackupToRef pushes the commit of the branch to the backup ref of the given name on the remote
+func BackupToRef(r *git.Repository, auth transport.AuthMethod, branch *plumbing.Reference, name string) (string, error) {
+	// a local ref to push from, the ref on the remote is of the same name
+	backup := plumbing.ReferenceName(BackupRefPrefix + name)
+	if err := r.Storer.SetReference(plumbing.NewHashReference(backup, branch.Hash())); err != nil {
+		return "", fmt.Errorf("failed to set backup ref: %w", err)
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("+%s:%s", backup, backup))
+	logrus.Infof("Backing up %s to %s", branch.Name().Short(), backup)
+	err := r.Push(&git.PushOptions{RemoteName: git.DefaultRemoteName, RefSpecs: []config.RefSpec{refSpec}, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", fmt.Errorf("failed to push backup ref: %w", err)
+	}
+	return backup.String(), nil
+}
+
+// WriteBundle writes the branch with all its history to a git bundle file, which can be read by git as well
+func WriteBundle(r *git.Repository, branch *plumbing.Reference, path string) error {
+	hashes, err := revlist.Objects(r.Storer, []plumbing.Hash{branch.Hash()}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if _, err = fmt.Fprintf(w, "%s%s %s\n\n", bundleSignature, branch.Hash(), branch.Name()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err = packfile.NewEncoder(w, r.Storer, false).Encode(hashes, 10); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to encode objects: %w", err)
+	}
+	if err = w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	logrus.Infof("Backing up %s to %s", branch.Name().Short(), path)
+	return f.Close()
+}
+
+// ReadBundle reads the objects of a git bundle file into the repo and returns the refs of the bundle,
+// bundles with prerequisites are not supported as the history should be complete
+func ReadBundle(r *git.Repository, path string) ([]*plumbing.Reference, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	signature, err := br.ReadString('\n')
+	if err != nil || signature != bundleSignature {
+		return nil, fmt.Errorf("not a v2 git bundle: %s", path)
+	}
+
+	var refs []*plumbing.Reference
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle header: %w", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "-") {
+			return nil, fmt.Errorf("bundle with prerequisites is not supported: %s", path)
+		}
+
+		hash, name, ok := strings.Cut(line, " ")
+		if !ok || !plumbing.IsHash(hash) {
+			return nil, fmt.Errorf("invalid bundle ref: %s", line)
+		}
+		refs = append(refs, plumbing.NewHashReference(plumbing.ReferenceName(name), plumbing.NewHash(hash)))
+	}
+
+	if err = packfile.UpdateObjectStorage(r.Storer, br); err != nil {
+		return nil, fmt.Errorf("failed to read bundle objects: %w", err)
+	}
+	return refs, nil
+}
+
+// FetchBackupRef fetches the backup ref from the remote and returns its commit
+func FetchBackupRef(r *git.Repository, auth transport.AuthMethod, ref string) (plumbing.Hash, error) {
+	name := plumbing.ReferenceName(ref)
+	if !strings.HasPrefix(ref, BackupRefPrefix) {
+		name = plumbing.ReferenceName(BackupRefPrefix + ref)
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("+%s:%s", name, name))
+	err := r.Fetch(&git.FetchOptions{RemoteName: git.DefaultRemoteName, RefSpecs: []config.RefSpec{refSpec}, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return plumbing.ZeroHash, fmt.Errorf("failed to fetch %s: %w", name, err)
+	}
+
+	backup, err := r.Reference(name, false)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	return backup.Hash(), nil
+}
+
+// RestoreBranch force pushes the commit to the branch of the remote, or deletes the branch on the remote
+// if the commit is the marker of AbsentBranch, the branch of HEAD if branch is empty
+func RestoreBranch(r *git.Repository, auth transport.AuthMethod, branch string, hash plumbing.Hash) error {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return err
+	}
+
+	c, err := r.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("failed to get commit: %w", err)
+	}
+	if c.NumParents() == 0 && c.Message == absentMessage {
+		return deleteBranch(r, auth, name)
+	}
+
+	if err = r.Storer.SetReference(plumbing.NewHashReference(name, hash)); err != nil {
+		return fmt.Errorf("failed to set branch: %w", err)
+	}
+	logrus.Infof("Restoring %s to %s", name.Short(), hash)
+	err = ForcePush(r, auth, name.Short())
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// deleteBranch deletes the branch on the remote, which is done already if the remote has no such branch
+func deleteBranch(r *git.Repository, auth transport.AuthMethod, name plumbing.ReferenceName) error {
+	refSpec := config.RefSpec(fmt.Sprintf(":%s", name))
+	logrus.Infof("Deleting %s which didn't exist before painting", name.Short())
+	err := r.Push(&git.PushOptions{RemoteName: git.DefaultRemoteName, RefSpecs: []config.RefSpec{refSpec}, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+	return nil
+}
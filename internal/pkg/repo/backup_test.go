@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteBundleAndReadBundle(t *testing.T) {
+	remote := newRemoteRepo(t)
+	r, err := CloneRepo(remote, nil)
+	assert.NoError(t, err)
+	commit(t, r, "Arbitrary commit #1")
+
+	branch, err := RemoteBranch(r, nil, "")
+	assert.NoError(t, err)
+	remoteRepo, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	head, err := remoteRepo.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, plumbing.NewHashReference(head.Name(), head.Hash()), branch)
+
+	path := filepath.Join(t.TempDir(), "backup.bundle")
+	assert.NoError(t, WriteBundle(r, branch, path))
+	if gitPath, err := exec.LookPath("git"); err == nil {
+		// the bundle can be cloned by git as well
+		out, err := exec.Command(gitPath, "clone", "--quiet", path, filepath.Join(t.TempDir(), "clone")).CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+
+	restored, err := git.Init(memory.NewStorage(), nil)
+	assert.NoError(t, err)
+	refs, err := ReadBundle(restored, path)
+	assert.NoError(t, err)
+	assert.Equal(t, []*plumbing.Reference{branch}, refs)
+	c, err := restored.CommitObject(branch.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, "initial commit", c.Message)
+}
+
+func TestRemoteBranch_NotExists(t *testing.T) {
+	r, err := CloneRepo(newRemoteRepo(t), nil)
+	assert.NoError(t, err)
+
+	branch, err := RemoteBranch(r, nil, "painting")
+	assert.NoError(t, err)
+	assert.Nil(t, branch)
+}
+
+func TestBackupToRefAndRestoreBranch(t *testing.T) {
+	remote := newRemoteRepo(t)
+	r, err := CloneRepo(remote, nil)
+	assert.NoError(t, err)
+	branch, err := RemoteBranch(r, nil, "")
+	assert.NoError(t, err)
+
+	ref, err := BackupToRef(r, nil, branch, "20240101T000000Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "refs/painter-backup/20240101T000000Z", ref)
+
+	// the branch is painted
+	painted := commit(t, r, "Arbitrary commit #1")
+	assert.NoError(t, ForcePush(r, nil, ""))
+
+	r, err = CloneRepo(remote, nil)
+	assert.NoError(t, err)
+	hash, err := FetchBackupRef(r, nil, "20240101T000000Z")
+	assert.NoError(t, err)
+	assert.Equal(t, branch.Hash(), hash)
+	assert.NotEqual(t, painted, hash)
+
+	assert.NoError(t, RestoreBranch(r, nil, "", hash))
+	remoteRepo, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	head, err := remoteRepo.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, branch.Hash(), head.Hash())
+}
+
+func TestAbsentBranchAndRestoreBranch(t *testing.T) {
+	remote := newRemoteRepo(t)
+	r, err := CloneRepo(remote, nil)
+	assert.NoError(t, err)
+	absent, err := AbsentBranch(r, "painting")
+	assert.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("painting"), absent.Name())
+
+	// the branch is painted
+	assert.NoError(t, CheckoutBranch(r, "painting", false))
+	commit(t, r, "Arbitrary commit #1")
+	assert.NoError(t, ForcePush(r, nil, "painting"))
+	painted, err := RemoteBranch(r, nil, "painting")
+	assert.NoError(t, err)
+	assert.NotNil(t, painted)
+
+	assert.NoError(t, RestoreBranch(r, nil, "painting", absent.Hash()))
+	remoteRepo, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	_, err = remoteRepo.Reference(plumbing.NewBranchReferenceName("painting"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
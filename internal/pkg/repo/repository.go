@@ -2,42 +2,154 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/sirupsen/logrus"
 )
 
 // CloneRepo Clones the given repository, creating the remote, the local branches
 // and fetching the objects, everything in memory
-func CloneRepo(repoUrl, ghToken string) (*git.Repository, error) {
+func CloneRepo(repoUrl string, auth transport.AuthMethod) (*git.Repository, error) {
 	logrus.Infof("Cloning repo: %s", repoUrl)
 	r, err := git.CloneContext(context.Background(), memory.NewStorage(), memfs.New(), &git.CloneOptions{
-		URL: repoUrl,
-		Auth: &http.BasicAuth{
-			Username: "token",
-			Password: ghToken,
-		},
+		URL:  repoUrl,
+		Auth: auth,
 	})
 	return r, err
 }
 
-func ForcePush(r *git.Repository, ghToken string) error {
+// OpenOrCloneRepo opens the repository at path on disk, or clones the given repository into path
+// if there is no repository yet, so the commits survive the process and can be inspected with git.
+// An opened repo is not fetched, see SyncBranch to bring it up to date with the remote
+func OpenOrCloneRepo(path, repoUrl string, auth transport.AuthMethod) (*git.Repository, error) {
+	r, err := git.PlainOpen(path)
+	if err == nil {
+		logrus.Infof("Opened repo: %s", path)
+		return r, checkOrigin(r, path, repoUrl)
+	}
+	if !errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("failed to open repo %s: %w", path, err)
+	}
+
+	logrus.Infof("Cloning repo: %s into %s", repoUrl, path)
+	return git.PlainCloneContext(context.Background(), path, false, &git.CloneOptions{
+		URL:  repoUrl,
+		Auth: auth,
+	})
+}
+
+// checkOrigin fails if the origin of the repo at path is not the given repository, so a repo on disk cloned
+// from another repository is not painted and pushed instead
+func checkOrigin(r *git.Repository, path, repoUrl string) error {
+	origin, err := r.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return fmt.Errorf("failed to get origin of repo %s: %w", path, err)
+	}
+	urls := origin.Config().URLs
+	for _, url := range urls {
+		if url == repoUrl {
+			return nil
+		}
+	}
+	return fmt.Errorf("repo %s is cloned from %s instead of %s, use another local path",
+		path, strings.Join(urls, ", "), repoUrl)
+}
+
+// CheckoutBranch checks out the branch to paint onto, the local branch if it exists, otherwise the remote branch,
+// otherwise a fresh root without history if orphan is set, otherwise a new branch from HEAD
+func CheckoutBranch(r *git.Repository, branch string, orphan bool) error {
+	w, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get work tree: %w", err)
+	}
+
+	local := plumbing.NewBranchReferenceName(branch)
+	if _, err = r.Reference(local, false); err == nil {
+		logrus.Infof("Checking out branch: %s", branch)
+		return w.Checkout(&git.CheckoutOptions{Branch: local})
+	}
+
+	// an orphan branch painted before is painted on again, a fresh root would drop the painting
+	remote, err := r.Reference(plumbing.NewRemoteReferenceName(git.DefaultRemoteName, branch), false)
+	if err == nil {
+		logrus.Infof("Checking out remote branch: %s", branch)
+		return w.Checkout(&git.CheckoutOptions{Branch: local, Hash: remote.Hash(), Create: true})
+	}
+
+	if orphan {
+		// HEAD points to the branch to be created by the first commit, the files stay untracked
+		logrus.Infof("Checking out orphan branch: %s", branch)
+		if err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, local)); err != nil {
+			return fmt.Errorf("failed to set HEAD: %w", err)
+		}
+		return r.Storer.SetIndex(&index.Index{Version: 2})
+	}
+
+	logrus.Infof("Creating branch: %s", branch)
+	return w.Checkout(&git.CheckoutOptions{Branch: local, Create: true})
+}
+
+// ForcePush pushes the branch to the remote branch of the same name with an explicit refspec,
+// the branch of HEAD if branch is empty
+func ForcePush(r *git.Repository, auth transport.AuthMethod, branch string) error {
 	logrus.Info("Force pushing changes")
-	err := r.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{
-			Username: "token",
-			Password: ghToken,
-		},
-		Force: true,
+	return push(r, auth, branch, true)
+}
+
+// Push pushes the branch like ForcePush, but only if the remote branch is fast-forwarded
+func Push(r *git.Repository, auth transport.AuthMethod, branch string) error {
+	logrus.Info("Pushing changes")
+	return push(r, auth, branch, false)
+}
+
+func push(r *git.Repository, auth transport.AuthMethod, branch string, force bool) error {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return err
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s", name, name))
+	if force {
+		refSpec = "+" + refSpec
+	}
+	logrus.Infof("Pushing %s", refSpec)
+	return r.Push(&git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       auth,
+		Force:      force,
 	})
-	return err
 }
 
+// branchName returns the reference name of the branch, the branch of HEAD if branch is empty
+func branchName(r *git.Repository, branch string) (plumbing.ReferenceName, error) {
+	if branch != "" {
+		return plumbing.NewBranchReferenceName(branch), nil
+	}
+
+	// HEAD of an orphan branch is not resolved before the first commit
+	head, err := r.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target(), nil
+	}
+	return "", fmt.Errorf("HEAD is not a branch: %s", head.Hash())
+}
+
+// GetCommits returns the history of the branch of HEAD, or from the commit of the options,
+// the commits of the other branches are left out
 func GetCommits(repo *git.Repository, opts *git.LogOptions) ([]*object.Commit, error) {
 	// Get the commit history
 	options := opts
@@ -45,7 +157,7 @@ func GetCommits(repo *git.Repository, opts *git.LogOptions) ([]*object.Commit, e
 		options = &git.LogOptions{}
 	}
 
-	commitIter, err := repo.CommitObjects()
+	commitIter, err := repo.Log(options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve commit history: %w", err)
 	}
@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSourceRepo creates a repo on disk with an empty commit to clone from
+func newSourceRepo(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "source")
+	r, err := git.PlainInit(path, false)
+	assert.NoError(t, err)
+
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	signature := &object.Signature{Name: "painter", Email: "painter@example.com", When: time.Now()}
+	_, err = w.Commit("initial commit", &git.CommitOptions{Author: signature, AllowEmptyCommits: true})
+	assert.NoError(t, err)
+
+	return path
+}
+
+func TestOpenOrCloneRepo(t *testing.T) {
+	source := newSourceRepo(t)
+	local := filepath.Join(t.TempDir(), "local")
+
+	// cloned into the local path at the first time
+	r, err := OpenOrCloneRepo(local, source, nil)
+	assert.NoError(t, err)
+	commit(t, r, "Arbitrary commit #1")
+
+	// opened with the commits at the second time
+	r, err = OpenOrCloneRepo(local, source, nil)
+	assert.NoError(t, err)
+	commits, err := GetCommits(r, nil)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+
+	// the repo on disk is of another repository
+	_, err = OpenOrCloneRepo(local, newSourceRepo(t), nil)
+	assert.ErrorContains(t, err, "is cloned from "+source+" instead of")
+
+	// the commits of the other branches are left out
+	assert.NoError(t, CheckoutBranch(r, "painting", true))
+	commit(t, r, "Arbitrary commit #2")
+	commits, err = GetCommits(r, nil)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+}
+
+// newRemoteRepo creates a bare repo on disk to stand in for the remote
+func newRemoteRepo(t *testing.T) string {
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	_, err := git.PlainClone(remote, true, &git.CloneOptions{URL: newSourceRepo(t)})
+	assert.NoError(t, err)
+	return remote
+}
+
+func commit(t *testing.T, r *git.Repository, msg string) plumbing.Hash {
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	hash, err := w.Commit(msg, &git.CommitOptions{
+		Author:            &object.Signature{Name: "painter", Email: "painter@example.com", When: time.Now()},
+		AllowEmptyCommits: true,
+	})
+	assert.NoError(t, err)
+	return hash
+}
+
+func TestForcePush(t *testing.T) {
+	remote := newRemoteRepo(t)
+
+	auth, err := NewAuthMethod("file://"+remote, Credentials{Token: "secret"})
+	assert.NoError(t, err)
+	r, err := CloneRepo("file://"+remote, auth)
+	assert.NoError(t, err)
+	hash := commit(t, r, "Arbitrary commit #1")
+
+	assert.NoError(t, ForcePush(r, auth, ""))
+
+	pushed, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	head, err := pushed.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, head.Hash())
+}
+
+func TestCheckoutBranch(t *testing.T) {
+	remote := newRemoteRepo(t)
+	remoteRepo, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	defaultHead, err := remoteRepo.Head()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		branch      string
+		orphan      bool
+		wantParents int
+	}{
+		{name: "new branch from HEAD", branch: "painting", wantParents: 1},
+		{name: "remote branch", branch: "painting", wantParents: 1},
+		{name: "orphan branch is a fresh root", branch: "orphan", orphan: true, wantParents: 0},
+		{name: "remote orphan branch is painted on again", branch: "orphan", orphan: true, wantParents: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := CloneRepo(remote, nil)
+			assert.NoError(t, err)
+
+			assert.NoError(t, CheckoutBranch(r, tt.branch, tt.orphan))
+			hash := commit(t, r, "Arbitrary commit #1")
+			assert.NoError(t, ForcePush(r, nil, tt.branch))
+
+			pushed, err := remoteRepo.Reference(plumbing.NewBranchReferenceName(tt.branch), false)
+			assert.NoError(t, err)
+			assert.Equal(t, hash, pushed.Hash())
+			c, err := remoteRepo.CommitObject(hash)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantParents, c.NumParents())
+
+			// the default branch is untouched
+			head, err := remoteRepo.Head()
+			assert.NoError(t, err)
+			assert.Equal(t, defaultHead.Hash(), head.Hash())
+		})
+	}
+
+	// the remote branch was checked out with the commit of the first run
+	painting, err := remoteRepo.Reference(plumbing.NewBranchReferenceName("painting"), false)
+	assert.NoError(t, err)
+	c, err := remoteRepo.CommitObject(painting.Hash())
+	assert.NoError(t, err)
+	parent, err := c.Parent(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Arbitrary commit #1", parent.Message)
+}
+
+func TestPush(t *testing.T) {
+	remote := newRemoteRepo(t)
+	r, err := CloneRepo(remote, nil)
+	assert.NoError(t, err)
+	other, err := CloneRepo(remote, nil)
+	assert.NoError(t, err)
+
+	// fast-forwarded
+	hash := commit(t, r, "Arbitrary commit #1")
+	assert.NoError(t, Push(r, nil, ""))
+	remoteRepo, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	head, err := remoteRepo.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, head.Hash())
+
+	// not fast-forwarded
+	commit(t, other, "Arbitrary commit #2")
+	assert.Error(t, Push(other, nil, ""))
+	head, err = remoteRepo.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, head.Hash())
+}
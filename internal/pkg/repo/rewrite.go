@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+)
+
+// RewriteWithout rewrites the history of the branch without the commits to drop, the other commits keep their
+// trees, authors and messages on top of the rewritten parents, the branch of HEAD if branch is empty.
+// The branch is moved to the rewritten head, and the dropped commits are returned.
+func RewriteWithout(r *git.Repository, branch string, drop func(c *object.Commit) bool) ([]*object.Commit, error) {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := r.Reference(name, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch %s: %w", name.Short(), err)
+	}
+
+	commits, err := parentsFirst(r, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// rewritten maps every commit to its rewritten commit, a dropped commit to the rewritten first parent,
+	// zero hash if nothing is left
+	rewritten := make(map[plumbing.Hash]plumbing.Hash)
+	var dropped []*object.Commit
+	for _, c := range commits {
+		var parents []plumbing.Hash
+		for _, parent := range c.ParentHashes {
+			if h := rewritten[parent]; !h.IsZero() && !contains(parents, h) {
+				parents = append(parents, h)
+			}
+		}
+
+		if drop(c) {
+			dropped = append(dropped, c)
+			if len(parents) > 0 {
+				rewritten[c.Hash] = parents[0]
+			}
+			continue
+		}
+
+		if equal(parents, c.ParentHashes) {
+			rewritten[c.Hash] = c.Hash
+			continue
+		}
+		rewritten[c.Hash], err = storeCommit(r, &object.Commit{
+			Author:       c.Author,
+			Committer:    c.Committer,
+			Message:      c.Message,
+			TreeHash:     c.TreeHash,
+			ParentHashes: parents,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	head := rewritten[ref.Hash()]
+	if head.IsZero() {
+		return nil, errors.New("every commit of the branch would be dropped")
+	}
+	if err = r.Storer.SetReference(plumbing.NewHashReference(name, head)); err != nil {
+		return nil, fmt.Errorf("failed to set branch: %w", err)
+	}
+
+	// keep the work tree in line with the rewritten branch if it is checked out
+	if current, err := r.Storer.Reference(plumbing.HEAD); err == nil && current.Target() == name {
+		w, err := r.Worktree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work tree: %w", err)
+		}
+		if err = w.Reset(&git.ResetOptions{Commit: head, Mode: git.HardReset}); err != nil {
+			return nil, fmt.Errorf("failed to reset work tree: %w", err)
+		}
+	}
+
+	logrus.Infof("Rewrote %s without %d commits", name.Short(), len(dropped))
+	return dropped, nil
+}
+
+// parentsFirst returns the commits reachable from the head, every commit comes after all its parents
+func parentsFirst(r *git.Repository, head plumbing.Hash) ([]*object.Commit, error) {
+	var ordered []*object.Commit
+	visited := make(map[plumbing.Hash]bool)
+
+	type frame struct {
+		commit   *object.Commit
+		expanded bool
+	}
+	c, err := r.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", head, err)
+	}
+	stack := []frame{{commit: c}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if top.expanded {
+			ordered = append(ordered, top.commit)
+			continue
+		}
+		if visited[top.commit.Hash] {
+			continue
+		}
+		visited[top.commit.Hash] = true
+
+		stack = append(stack, frame{commit: top.commit, expanded: true})
+		for _, parent := range top.commit.ParentHashes {
+			if visited[parent] {
+				continue
+			}
+			p, err := r.CommitObject(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commit %s: %w", parent, err)
+			}
+			stack = append(stack, frame{commit: p})
+		}
+	}
+	return ordered, nil
+}
+
+func storeCommit(r *git.Repository, c *object.Commit) (plumbing.Hash, error) {
+	obj := r.Storer.NewEncodedObject()
+	if err := c.Encode(obj); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode commit: %w", err)
+	}
+	return r.Storer.SetEncodedObject(obj)
+}
+
+func contains(hashes []plumbing.Hash, h plumbing.Hash) bool {
+	for _, hash := range hashes {
+		if hash == h {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b []plumbing.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func messages(t *testing.T, r *git.Repository, from plumbing.Hash) []string {
+	iter, err := r.Log(&git.LogOptions{From: from})
+	assert.NoError(t, err)
+	var msgs []string
+	assert.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		msgs = append(msgs, c.Message)
+		return nil
+	}))
+	return msgs
+}
+
+func TestRewriteWithout(t *testing.T) {
+	r, err := CloneRepo(newRemoteRepo(t), nil)
+	assert.NoError(t, err)
+	initial, err := r.Head()
+	assert.NoError(t, err)
+	commit(t, r, "painted #1")
+	kept := commit(t, r, "work")
+	commit(t, r, "painted #2")
+
+	dropped, err := RewriteWithout(r, "", func(c *object.Commit) bool {
+		return strings.HasPrefix(c.Message, "painted")
+	})
+	assert.NoError(t, err)
+	assert.Len(t, dropped, 2)
+
+	head, err := r.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"work", "initial commit"}, messages(t, r, head.Hash()))
+	assert.NotEqual(t, kept, head.Hash())
+
+	rewritten, err := r.CommitObject(head.Hash())
+	assert.NoError(t, err)
+	original, err := r.CommitObject(kept)
+	assert.NoError(t, err)
+	assert.Equal(t, original.Author, rewritten.Author)
+	assert.Equal(t, original.TreeHash, rewritten.TreeHash)
+	// the commits before the first dropped one are kept as they are
+	assert.Equal(t, []plumbing.Hash{initial.Hash()}, rewritten.ParentHashes)
+
+	status, err := mustWorktree(t, r).Status()
+	assert.NoError(t, err)
+	assert.True(t, status.IsClean())
+}
+
+func TestRewriteWithout_DropEverything(t *testing.T) {
+	r, err := CloneRepo(newRemoteRepo(t), nil)
+	assert.NoError(t, err)
+
+	_, err = RewriteWithout(r, "", func(c *object.Commit) bool { return true })
+	assert.EqualError(t, err, "every commit of the branch would be dropped")
+}
+
+func mustWorktree(t *testing.T, r *git.Repository) *git.Worktree {
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	return w
+}
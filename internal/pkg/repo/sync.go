@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrDiverged is returned when the branch is neither behind nor ahead of the commit
+var ErrDiverged = errors.New("branch has diverged")
+
+// FastForward moves the branch to the commit if the branch is behind it, a branch at or ahead of the commit is
+// left as it is, and a branch diverged from it fails with ErrDiverged. The work tree is reset if the branch is
+// checked out, the branch of HEAD if branch is empty.
+func FastForward(r *git.Repository, branch string, to plumbing.Hash) error {
+	name, err := branchName(r, branch)
+	if err != nil {
+		return err
+	}
+	ref, err := r.Reference(name, true)
+	if err != nil {
+		return fmt.Errorf("failed to get branch %s: %w", name.Short(), err)
+	}
+	if ref.Hash() == to {
+		return nil
+	}
+
+	local, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to get commit %s: %w", ref.Hash(), err)
+	}
+	target, err := r.CommitObject(to)
+	if err != nil {
+		return fmt.Errorf("failed to get commit %s: %w", to, err)
+	}
+
+	if ahead, err := target.IsAncestor(local); err != nil || ahead {
+		return err
+	}
+	behind, err := local.IsAncestor(target)
+	if err != nil {
+		return err
+	}
+	if !behind {
+		return fmt.Errorf("%s %s -> %s: %w", name.Short(), ref.Hash(), to, ErrDiverged)
+	}
+
+	logrus.Infof("Fast-forwarding %s to %s", name.Short(), to)
+	if head, err := r.Storer.Reference(plumbing.HEAD); err == nil && head.Target() == name {
+		w, err := r.Worktree()
+		if err != nil {
+			return fmt.Errorf("failed to get work tree: %w", err)
+		}
+		if err = w.Reset(&git.ResetOptions{Commit: to, Mode: git.HardReset}); err != nil {
+			return fmt.Errorf("failed to reset work tree: %w", err)
+		}
+		return nil
+	}
+	if err = r.Storer.SetReference(plumbing.NewHashReference(name, to)); err != nil {
+		return fmt.Errorf("failed to set branch: %w", err)
+	}
+	return nil
+}
+
+// SyncBranch fetches the remote and fast-forwards the branch of a repo on disk to the remote branch, so it is
+// painted on top of the commits pushed since the last run, the branch of HEAD if branch is empty.
+// A branch diverged from the remote branch, e.g. rewritten by erasing in a dry run, is kept only if the remote
+// branch hasn't moved since it was last fetched, otherwise force pushing it would drop the commits pushed since.
+func SyncBranch(r *git.Repository, auth transport.AuthMethod, branch string) error {
+	before, err := TrackedBranch(r, branch)
+	if err != nil {
+		return err
+	}
+	after, err := RemoteBranch(r, auth, branch)
+	if err != nil || after == nil {
+		return err
+	}
+
+	// nothing to sync on a branch without commits yet
+	if _, err = r.Reference(after.Name(), true); errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil
+	}
+
+	err = FastForward(r, branch, after.Hash())
+	if errors.Is(err, ErrDiverged) && before != nil && before.Hash() == after.Hash() {
+		logrus.Infof("Keeping %s diverged from the remote, which hasn't moved since it was last fetched",
+			after.Name().Short())
+		return nil
+	}
+	if errors.Is(err, ErrDiverged) {
+		return fmt.Errorf("the remote has moved since it was last fetched, %w", err)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		// local commits in the repo on disk, after the remote was last fetched
+		local int
+		// commits pushed to the remote by someone else, after the remote was last fetched
+		pushed int
+		// whether the local history is replaced by a fresh root, e.g. erased in a dry run
+		rewritten bool
+		wantErr   error
+		// whether the branch ends up at the remote branch
+		wantRemote bool
+	}{
+		{name: "up to date", wantRemote: true},
+		{name: "behind is fast-forwarded", pushed: 2, wantRemote: true},
+		{name: "ahead is kept", local: 1},
+		{name: "diverged from an unmoved remote is kept", local: 1, rewritten: true},
+		{name: "diverged from a moved remote is refused", local: 1, pushed: 1, wantErr: ErrDiverged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := newRemoteRepo(t)
+			local := filepath.Join(t.TempDir(), "local")
+			r, err := OpenOrCloneRepo(local, remote, nil)
+			assert.NoError(t, err)
+
+			other, err := CloneRepo(remote, nil)
+			assert.NoError(t, err)
+			for i := 0; i < tt.pushed; i++ {
+				commit(t, other, "Pushed commit")
+			}
+			if tt.pushed > 0 {
+				assert.NoError(t, Push(other, nil, ""))
+			}
+
+			var localHash plumbing.Hash
+			for i := 0; i < tt.local; i++ {
+				localHash = commit(t, r, "Local commit")
+			}
+			if tt.rewritten {
+				w, err := r.Worktree()
+				assert.NoError(t, err)
+				head, err := r.Head()
+				assert.NoError(t, err)
+				c, err := r.CommitObject(head.Hash())
+				assert.NoError(t, err)
+				c.ParentHashes = nil
+				obj := r.Storer.NewEncodedObject()
+				assert.NoError(t, c.Encode(obj))
+				localHash, err = r.Storer.SetEncodedObject(obj)
+				assert.NoError(t, err)
+				assert.NoError(t, w.Reset(&git.ResetOptions{Commit: localHash, Mode: git.HardReset}))
+			}
+
+			r, err = OpenOrCloneRepo(local, remote, nil)
+			assert.NoError(t, err)
+			err = SyncBranch(r, nil, "")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "%v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			head, err := r.Head()
+			assert.NoError(t, err)
+			if tt.wantRemote {
+				remoteRepo, err := git.PlainOpen(remote)
+				assert.NoError(t, err)
+				remoteHead, err := remoteRepo.Head()
+				assert.NoError(t, err)
+				assert.Equal(t, remoteHead.Hash(), head.Hash())
+			} else {
+				assert.Equal(t, localHash, head.Hash())
+			}
+		})
+	}
+}
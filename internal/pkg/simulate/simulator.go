@@ -5,6 +5,10 @@ import (
 	"contribution-painter/internal/pkg/dict"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -16,30 +20,128 @@ const (
 	bgIcon     = "\u25a2 "
 	targetIcon = "\u2588 "
 
+	iconWidth = 2 // every icon is a square and a space
+
 	minLength = 5
 	minHeight = 5
 )
 
+// weekdayLabels are written on the left of a calendar, like GitHub only Mon, Wed and Fri are labelled
+var weekdayLabels = []string{"    ", "Mon ", "    ", "Wed ", "    ", "Fri ", "    "}
+
 func printMatrix(matrix [][]uint) {
+	_ = writeMatrix(os.Stderr, matrix, time.Time{})
+}
+
+// writeMatrix writes the matrix to w, if startDate is set, the matrix is a calendar whose first column is
+// the week of startDate, and the month headers and weekday labels are written around it
+func writeMatrix(w io.Writer, matrix [][]uint, startDate time.Time) error {
+	calendar := !startDate.IsZero()
+	if calendar && len(matrix) > 0 {
+		if _, err := fmt.Fprintln(w, strings.Repeat(" ", len(weekdayLabels[0]))+monthHeader(startDate, len(matrix[0]))); err != nil {
+			return err
+		}
+	}
+
 	for i := 0; i < len(matrix); i++ {
+		var sb strings.Builder
+		if calendar {
+			if i < len(weekdayLabels) {
+				sb.WriteString(weekdayLabels[i])
+			} else {
+				sb.WriteString(weekdayLabels[0])
+			}
+		}
 		for j := 0; j < len(matrix[i]); j++ {
-			if matrix[i][j] == 1 {
-				print(targetIcon)
+			if matrix[i][j] > 0 {
+				sb.WriteString(targetIcon)
 			} else {
-				print(bgIcon)
+				sb.WriteString(bgIcon)
+			}
+		}
+		if _, err := fmt.Fprintln(w, sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// monthHeader returns the month labels of the columns, a month is labelled at the week it starts in,
+// the label of the partial first month gives way to the next month if they overlap
+func monthHeader(startDate time.Time, columns int) string {
+	header := []rune(strings.Repeat(" ", columns*iconWidth))
+	lastColumn, lastEnd := -1, -1
+	for column := 0; column < columns; column++ {
+		weekStart := startDate.AddDate(0, 0, column*7)
+		weekEnd := weekStart.AddDate(0, 0, 6)
+		if column > 0 && weekStart.Day() != 1 && weekEnd.Month() == weekStart.Month() {
+			continue
+		}
+
+		label := []rune(weekEnd.Format("Jan"))
+		pos := column * iconWidth
+		if pos+len(label) > len(header) {
+			break
+		}
+		if pos <= lastEnd {
+			if lastColumn != 0 || startDate.Day() == 1 {
+				continue
+			}
+			copy(header, []rune(strings.Repeat(" ", lastEnd)))
+		}
+		copy(header[pos:], label)
+		lastColumn, lastEnd = column, pos+len(label)
+	}
+
+	return strings.TrimRight(string(header), " ")
+}
+
+// RenderLetters writes the letters side by side to w
+func RenderLetters(w io.Writer, letters []domain.Letter) error {
+	height := 0
+	for _, letter := range letters {
+		if len(letter) > height {
+			height = len(letter)
+		}
+	}
+
+	for i := 0; i < height; i++ {
+		var sb strings.Builder
+		for _, letter := range letters {
+			for j := range letter[0] {
+				if i < len(letter) && letter[i][j] > 0 {
+					sb.WriteString(targetIcon)
+				} else {
+					sb.WriteString(bgIcon)
+				}
 			}
 		}
-		println()
+		if _, err := fmt.Fprintln(w, sb.String()); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
+// Simulator prints the letters laid onto a background of bgLength weeks and bgHeight days
 type Simulator struct {
 	bgLength int
 	bgHeight int
 
+	letterSpacing int
+	leadingSpace  int
+	trailingSpace int
+	topSpace      int
+
+	// startDate is the Sunday of the first column, the background is printed as a calendar if set
+	startDate time.Time
+	out       io.Writer
+
 	dict domain.Dictionary
 }
 
+var _ domain.Simulator = (*Simulator)(nil)
+
 func NewSimulator(length, height int, font domain.Font) *Simulator {
 	if length < minLength {
 		logrus.Warnf("length is too short: %d, set to %d", length, minLength)
@@ -55,52 +157,69 @@ func NewSimulator(length, height int, font domain.Font) *Simulator {
 	if f == "" {
 		f = domain.Font75
 	}
-	return &Simulator{bgLength: length, bgHeight: height, dict: dict.NewDictionary(f)}
+	return &Simulator{bgLength: length, bgHeight: height, dict: dict.NewDictionary(f), out: os.Stderr}
 }
 
-func (s *Simulator) Simulate(target string, letterSpacing, leadingSpace, trailingSpace, topSpace int) error {
+// NewCalendarSimulator creates a simulator of the contribution calendar whose first column is the week of
+// startDate, the letters are laid out like the rewriter paints them
+func NewCalendarSimulator(w io.Writer, startDate time.Time, d domain.Dictionary,
+	letterSpacing, leadingSpace, trailingSpace, topSpace int) *Simulator {
+	return &Simulator{
+		bgLength:      domain.CalendarWidth,
+		bgHeight:      domain.CalendarHeight,
+		letterSpacing: letterSpacing,
+		leadingSpace:  leadingSpace,
+		trailingSpace: trailingSpace,
+		topSpace:      topSpace,
+		startDate:     startDate,
+		out:           w,
+		dict:          d,
+	}
+}
+
+// Simulate prints the target letters with the spacing of the simulator
+func (s *Simulator) Simulate(target string) error {
 	if len(target) == 0 {
 		return errors.New("target is empty")
 	}
 
-	letters, err := s.dict.GetLetters(target, letterSpacing, leadingSpace, trailingSpace)
+	letters, err := s.dict.GetLetters(target, s.letterSpacing, s.leadingSpace, s.trailingSpace)
 	if err != nil {
 		return fmt.Errorf("failed to get letters: %w", err)
 	}
 
-	if s.dict.FontHeight()+topSpace > s.bgHeight {
-		return fmt.Errorf("letters are too high: %d, %d", s.dict.FontHeight(), s.bgHeight)
-	}
+	return s.SimulateLetters(letters)
+}
 
-	// concat letters
-	matrix := make([][]uint, s.bgHeight)
-	column := 0
+// SimulateLetters prints the letters side by side on the background, it fails if they don't fit
+func (s *Simulator) SimulateLetters(letters []domain.Letter) error {
+	height, length := 0, 0
 	for _, letter := range letters {
-		if column > s.bgLength {
-			return fmt.Errorf("letters are too long: %d, %d", column, s.bgLength)
+		if len(letter) > height {
+			height = len(letter)
 		}
+		length += len(letter[0])
+	}
 
-		// add top space
-		letterWidth := letter[0]
-		for i := 0; i < topSpace; i++ {
-			matrix[i] = make([]uint, column+len(letterWidth))
-		}
+	if height+s.topSpace > s.bgHeight {
+		return fmt.Errorf("letters are too high: %d, %d", height, s.bgHeight)
+	}
+	if length > s.bgLength {
+		return fmt.Errorf("letters are too long: %d, %d", length, s.bgLength)
+	}
 
-		// add letters to matrix
-		for j := topSpace; j < len(matrix); j++ {
-			if j-topSpace >= len(letter) {
-				matrix[j] = append(matrix[j], make([]uint, len(letterWidth))...)
-				continue
-			}
-			if j-topSpace >= len(letter) {
-				continue
-			}
-			matrix[j] = append(matrix[j], letter[j-topSpace]...)
-		}
+	matrix := make([][]uint, s.bgHeight)
+	for i := range matrix {
+		matrix[i] = make([]uint, s.bgLength)
+	}
 
-		column += len(letterWidth)
+	column := 0
+	for _, letter := range letters {
+		for i, row := range letter {
+			copy(matrix[s.topSpace+i][column:], row)
+		}
+		column += len(letter[0])
 	}
 
-	printMatrix(matrix)
-	return nil
+	return writeMatrix(s.out, matrix, s.startDate)
 }
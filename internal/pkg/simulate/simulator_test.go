@@ -1,10 +1,14 @@
 package simulate
 
 import (
+	"bytes"
 	"contribution-painter/internal/domain"
 	"contribution-painter/internal/pkg/dict"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -88,6 +92,17 @@ func Test_printMatrix(t *testing.T) {
 	}
 }
 
+func TestRenderLetters(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderLetters(&buf, []domain.Letter{
+		{{1}, {0}},
+		{{0, 1}, {1, 0}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, targetIcon+bgIcon+targetIcon+"\n"+bgIcon+targetIcon+bgIcon+"\n", buf.String())
+}
+
 func TestSimulator_Simulate(t *testing.T) {
 	type args struct {
 		target        string
@@ -144,22 +159,87 @@ func TestSimulator_Simulate(t *testing.T) {
 			},
 			wantErr: errors.New("letters are too high: 5, 7"),
 		},
+		{
+			name:     "too long letters should return error",
+			bgLength: 20,
+			bgHeight: 7,
+			font:     domain.Font75,
+			args: args{
+				target:        "HELLO",
+				letterSpacing: 2,
+			},
+			wantErr: errors.New("letters are too long: 33, 20"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Simulator{
-				bgLength: tt.bgLength,
-				bgHeight: tt.bgHeight,
-				dict:     dict.NewDictionary(tt.font),
+				bgLength:      tt.bgLength,
+				bgHeight:      tt.bgHeight,
+				letterSpacing: tt.args.letterSpacing,
+				leadingSpace:  tt.args.leadingSpace,
+				trailingSpace: tt.args.trailingSpace,
+				topSpace:      tt.args.topSpace,
+				out:           io.Discard,
+				dict:          dict.NewDictionary(tt.font),
 			}
 
-			err := s.Simulate(tt.args.target, tt.args.letterSpacing, tt.args.leadingSpace, tt.args.trailingSpace, tt.args.topSpace)
+			err := s.Simulate(tt.args.target)
 
 			if tt.wantErr != nil {
-				assert.Error(t, err)
+				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
 				assert.NoError(t, err)
 			}
 		})
 	}
 }
+
+func TestNewCalendarSimulator(t *testing.T) {
+	var buf bytes.Buffer
+	startDate := time.Date(2023, 10, 29, 0, 0, 0, 0, time.UTC)
+	s := NewCalendarSimulator(&buf, startDate, dict.NewDictionary(domain.Font55), 1, 2, 0, 1)
+
+	err := s.Simulate("HI")
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, domain.CalendarHeight+1)
+	// the week of Oct 29 ends in November
+	assert.True(t, strings.HasPrefix(lines[0], "    Nov     Dec"), lines[0])
+	assert.Equal(t, "Mon "+strings.Repeat(bgIcon, 2)+targetIcon+bgIcon, lines[2][:len("Mon ")+4*len(bgIcon)])
+	assert.Equal(t, domain.CalendarWidth, strings.Count(lines[1], bgIcon))
+}
+
+func Test_monthHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		startDate time.Time
+		columns   int
+		want      string
+	}{
+		{
+			name:      "label at the week a month starts in",
+			startDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			columns:   10,
+			want:      "Jan     Feb     Mar",
+		},
+		{
+			name:      "partial first month gives way",
+			startDate: time.Date(2023, 1, 22, 0, 0, 0, 0, time.UTC),
+			columns:   6,
+			want:      "  Feb",
+		},
+		{
+			name:      "label beyond the last column is skipped",
+			startDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			columns:   5,
+			want:      "Jan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, monthHeader(tt.startDate, tt.columns))
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"contribution-painter/internal/pkg/helper"
 	"fmt"
 	"sort"
-	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -41,7 +40,7 @@ func (c *ContributionStats) PrintCommitStat(stats ...ContributionStat) (err erro
 }
 
 func (c *ContributionStats) GetContributionStats() ([]ContributionStat, error) {
-	resp, err := c.ghGraphql.GetContributionCollection()
+	resp, err := c.ghGraphql.GetContributionCollection(graphql.DateRange{})
 	if err != nil {
 		return nil, err
 	}
@@ -57,8 +56,9 @@ func (c *ContributionStats) GetContributionStats() ([]ContributionStat, error) {
 	return convertToContributionStats(groupByColor), nil
 }
 
-func (c *ContributionStats) CommitsByDay() ([]CommitStat, error) {
-	resp, err := c.ghGraphql.GetContributionCollection()
+// CommitsByDay returns the commits of every day in the date range, of the trailing year if the range is zero
+func (c *ContributionStats) CommitsByDay(dateRange graphql.DateRange) ([]CommitStat, error) {
+	resp, err := c.ghGraphql.GetContributionCollection(dateRange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contribution collection: %w", err)
 	}
@@ -71,7 +71,7 @@ func (c *ContributionStats) CommitsByDay() ([]CommitStat, error) {
 
 	for _, week := range contributionDays {
 		for _, day := range week.ContributionDays {
-			date, err := time.Parse(helper.DateFormat, day.Date)
+			date, err := helper.ParseDay(day.Date)
 			if err != nil {
 				logrus.WithError(err).Error("failed to parse date")
 				continue
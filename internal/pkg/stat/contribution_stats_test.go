@@ -81,6 +81,21 @@ func TestCommitsByDay(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "timestamps should be parsed as the days of the calendar",
+			handler: func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusOK)
+				_, _ = writer.Write([]byte(`{"data": {"user": {"contributionsCollection": {"contributionCalendar": {
+					"weeks": [{"contributionDays": [
+						{"date": "2023-06-18T00:00:00.000+08:00", "contributionCount": 3},
+						{"date": "2023-06-19T00:00:00.000-07:00", "contributionCount": 4}
+					]}]}}}}}`))
+			},
+			want: []CommitStat{
+				{Date: time.Date(2023, 6, 18, 0, 0, 0, 0, time.UTC), Commits: 3},
+				{Date: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC), Commits: 4},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,7 +110,7 @@ func TestCommitsByDay(t *testing.T) {
 				},
 			})
 
-			commitStats, err := c.CommitsByDay()
+			commitStats, err := c.CommitsByDay(graphql.DateRange{})
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
@@ -0,0 +1,131 @@
+package stat
+
+import (
+	"contribution-painter/internal/domain"
+	"contribution-painter/internal/pkg/helper"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LevelThresholds are the upper bounds of the daily counts of level 1, 2 and 3, counts above the last
+// threshold are level 4.
+//
+// It models how GitHub colours the contribution calendar: days without contribution are level 0, the
+// other days are split by the quartiles of the non-zero daily counts over the visible year.
+type LevelThresholds [domain.MaxLevel - 1]int
+
+// PredictThresholds returns the quartiles of the non-zero counts, using the nearest-rank method
+func PredictThresholds(counts []int) LevelThresholds {
+	var nonZero []int
+	for _, c := range counts {
+		if c > 0 {
+			nonZero = append(nonZero, c)
+		}
+	}
+
+	var t LevelThresholds
+	if len(nonZero) == 0 {
+		return t
+	}
+
+	sort.Ints(nonZero)
+	for k := range t {
+		rank := ((k+1)*len(nonZero) + domain.MaxLevel - 1) / domain.MaxLevel // ceil(k/4 * n)
+		t[k] = nonZero[rank-1]
+	}
+	return t
+}
+
+// Level returns the colour level of the daily count
+func (t LevelThresholds) Level(count int) int {
+	if count <= 0 {
+		return 0
+	}
+	for k, threshold := range t {
+		if count <= threshold {
+			return k + 1
+		}
+	}
+	return domain.MaxLevel
+}
+
+// SuggestCommitsPerLevel returns the minimal commits per day of level 0 - 4 so that every target day ends up
+// in its target level once painted, given the existing commits by day of the visible year.
+// Painting only adds commits, a target day keeps its existing count if it is already above its level.
+func SuggestCommitsPerLevel(existing []CommitStat, targets map[time.Time]int) ([domain.MaxLevel + 1]int, error) {
+	var commits [domain.MaxLevel + 1]int
+
+	// every target day of a level is painted to the same count, at least the max existing count of them
+	used := make(map[int]bool)
+	maxExisting := 0
+	existingByDay := make(map[time.Time]int)
+	for _, cs := range existing {
+		existingByDay[cs.Date] = cs.Commits
+		if cs.Commits > maxExisting {
+			maxExisting = cs.Commits
+		}
+	}
+	for date, level := range targets {
+		if level < 0 || level > domain.MaxLevel {
+			return commits, fmt.Errorf("level %d of %s is not in [0, %d]", level, date.Format(helper.DateFormat), domain.MaxLevel)
+		}
+		if level == 0 && existingByDay[date] > 0 {
+			return commits, fmt.Errorf("level 0 cannot be reached on %s, it has %d commits", date.Format(helper.DateFormat), existingByDay[date])
+		}
+		used[level] = true
+		if existingByDay[date] > commits[level] {
+			commits[level] = existingByDay[date]
+		}
+	}
+
+	for {
+		for k := 1; k <= domain.MaxLevel; k++ {
+			if commits[k] <= commits[k-1] {
+				commits[k] = commits[k-1] + 1
+			}
+		}
+
+		thresholds := predictPaintedThresholds(existingByDay, targets, commits)
+		tooLow, tooHigh := 0, 0
+		for k := 1; k <= domain.MaxLevel; k++ {
+			if !used[k] {
+				continue
+			}
+			switch level := thresholds.Level(commits[k]); {
+			case level < k && tooLow == 0:
+				tooLow = k
+			case level > k && tooHigh == 0:
+				tooHigh = k
+			}
+		}
+
+		switch {
+		case tooLow == 0 && tooHigh == 0:
+			return commits, nil
+		case tooLow == 0:
+			return commits, fmt.Errorf("level %d cannot be reached, the existing commits are too many", tooHigh)
+		case commits[tooLow] > maxExisting+domain.MaxLevel:
+			// above all existing counts the quartiles only depend on how many days are painted in each level
+			return commits, fmt.Errorf("level %d cannot be reached, too many days are painted in level %d or above", tooLow, tooLow)
+		}
+		commits[tooLow]++
+	}
+}
+
+// predictPaintedThresholds returns the thresholds of the calendar after painting the targets
+func predictPaintedThresholds(existing map[time.Time]int, targets map[time.Time]int, commits [domain.MaxLevel + 1]int) LevelThresholds {
+	counts := make([]int, 0, len(existing)+len(targets))
+	for date, count := range existing {
+		if level, ok := targets[date]; ok && commits[level] > count {
+			count = commits[level]
+		}
+		counts = append(counts, count)
+	}
+	for date, level := range targets {
+		if _, ok := existing[date]; !ok {
+			counts = append(counts, commits[level])
+		}
+	}
+	return PredictThresholds(counts)
+}
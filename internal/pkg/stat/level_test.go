@@ -0,0 +1,120 @@
+package stat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictThresholds(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   LevelThresholds
+		levels map[int]int
+	}{
+		{
+			name:   "empty calendar should be all level 0",
+			counts: []int{0, 0, 0},
+			want:   LevelThresholds{0, 0, 0},
+			levels: map[int]int{0: 0},
+		},
+		{
+			name:   "quartiles should ignore days without contributions",
+			counts: []int{0, 0, 4, 1, 3, 2},
+			want:   LevelThresholds{1, 2, 3},
+			levels: map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 4, 100: 4},
+		},
+		{
+			name:   "quartiles should use the nearest rank",
+			counts: []int{1, 1, 1, 1, 1, 1, 5, 9},
+			want:   LevelThresholds{1, 1, 1},
+			levels: map[int]int{1: 1, 5: 4, 9: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PredictThresholds(tt.counts)
+			assert.Equal(t, tt.want, got)
+			for count, level := range tt.levels {
+				assert.Equalf(t, level, got.Level(count), "level of %d", count)
+			}
+		})
+	}
+}
+
+func TestSuggestCommitsPerLevel(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time {
+		return start.AddDate(0, 0, n)
+	}
+
+	tests := []struct {
+		name     string
+		existing []CommitStat
+		targets  map[time.Time]int
+		want     [5]int
+		wantErr  string
+	}{
+		{
+			name: "empty calendar should need a commit per level",
+			targets: func() map[time.Time]int {
+				targets := make(map[time.Time]int)
+				for i := 0; i < 20; i++ {
+					targets[day(i)] = 1
+				}
+				for i := 20; i < 24; i++ {
+					targets[day(i)] = 4
+				}
+				return targets
+			}(),
+			want: [5]int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "foreground should be above the existing quartiles",
+			existing: func() []CommitStat {
+				var existing []CommitStat
+				for i := 0; i < 18; i++ {
+					existing = append(existing, CommitStat{Date: day(i)})
+				}
+				for i := 10; i < 18; i++ {
+					existing[i].Commits = 10
+				}
+				return existing
+			}(),
+			targets: func() map[time.Time]int {
+				targets := make(map[time.Time]int)
+				for i := 0; i < 8; i++ {
+					targets[day(i)] = 1
+				}
+				targets[day(8)], targets[day(9)] = 4, 4
+				return targets
+			}(),
+			want: [5]int{0, 1, 2, 3, 11},
+		},
+		{
+			name:    "foreground of too many days should return error",
+			targets: map[time.Time]int{day(0): 4, day(1): 4, day(2): 4, day(3): 4},
+			wantErr: "level 4 cannot be reached, too many days are painted in level 4 or above",
+		},
+		{
+			name:     "level 0 on a day with commits should return error",
+			existing: []CommitStat{{Date: day(0), Commits: 3}},
+			targets:  map[time.Time]int{day(0): 0},
+			wantErr:  "level 0 cannot be reached on 2023-01-01, it has 3 commits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SuggestCommitsPerLevel(tt.existing, tt.targets)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}